@@ -0,0 +1,191 @@
+package analysis
+
+import (
+	"github.com/andrskom/gowfnet/cfg"
+)
+
+// Classes of config, a config can belong to many classes.
+type Classes struct {
+	StateMachine       bool `json:"stateMachine"`
+	MarkedGraph        bool `json:"markedGraph"`
+	FreeChoice         bool `json:"freeChoice"`
+	ExtendedFreeChoice bool `json:"extendedFreeChoice"`
+	WorkflowNet        bool `json:"workflowNet"`
+}
+
+// Conflict is a pair of transitions with the shared input place which breaks a free-choice property.
+type Conflict struct {
+	Place       string   `json:"place"`
+	Transitions []string `json:"transitions"`
+}
+
+// Classify config by structure.
+func Classify(c cfg.Interface) Classes {
+	return Classes{
+		StateMachine:       IsStateMachine(c),
+		MarkedGraph:        IsMarkedGraph(c),
+		FreeChoice:         IsFreeChoice(c),
+		ExtendedFreeChoice: IsExtendedFreeChoice(c),
+		WorkflowNet:        IsWorkflowNet(c),
+	}
+}
+
+// IsStateMachine returns true if each transition has exactly one input and one output place.
+func IsStateMachine(c cfg.Interface) bool {
+	return len(NonStateMachineTransitions(c)) == 0
+}
+
+// NonStateMachineTransitions returns sorted ids of transitions which haven't exactly one input and one output place.
+func NonStateMachineTransitions(c cfg.Interface) []string {
+	n := newNet(c)
+	res := make([]string, 0)
+
+	for _, transition := range n.transitions {
+		if len(n.pre[transition]) != 1 || len(n.post[transition]) != 1 {
+			res = append(res, transition)
+		}
+	}
+
+	return res
+}
+
+// IsMarkedGraph returns true if each place has exactly one input and one output transition.
+func IsMarkedGraph(c cfg.Interface) bool {
+	return len(NonMarkedGraphPlaces(c)) == 0
+}
+
+// NonMarkedGraphPlaces returns sorted ids of places which haven't exactly one input and one output transition.
+// The start place is without input transitions and the finish place is without output transitions in a workflow,
+// so they break the property too.
+func NonMarkedGraphPlaces(c cfg.Interface) []string {
+	n := newNet(c)
+	res := make([]string, 0)
+
+	for _, place := range n.places {
+		if len(n.placePre[place]) != 1 || len(n.placePost[place]) != 1 {
+			res = append(res, place)
+		}
+	}
+
+	return res
+}
+
+// IsFreeChoice returns true if transitions with a shared input place have only this input place.
+func IsFreeChoice(c cfg.Interface) bool {
+	return len(FreeChoiceConflicts(c)) == 0
+}
+
+// FreeChoiceConflicts returns pairs of transitions which share an input place,
+// but at least one of them has another input place.
+func FreeChoiceConflicts(c cfg.Interface) []Conflict {
+	return findConflicts(newNet(c), func(a []string, b []string) bool {
+		return len(a) == 1 && len(b) == 1
+	})
+}
+
+// IsExtendedFreeChoice returns true if transitions with a shared input place have the same input places.
+func IsExtendedFreeChoice(c cfg.Interface) bool {
+	return len(ExtendedFreeChoiceConflicts(c)) == 0
+}
+
+// ExtendedFreeChoiceConflicts returns pairs of transitions which share an input place,
+// but have different input places.
+func ExtendedFreeChoiceConflicts(c cfg.Interface) []Conflict {
+	return findConflicts(newNet(c), equalIDs)
+}
+
+// findConflicts returns pairs of transitions with a shared input place and input places which aren't allowed.
+// Pairs are ordered by the place and ids of transitions.
+func findConflicts(n *net, allowed func(a []string, b []string) bool) []Conflict {
+	res := make([]Conflict, 0)
+
+	for _, place := range n.places {
+		transitions := n.placePost[place]
+
+		for i := 0; i < len(transitions); i++ {
+			for j := i + 1; j < len(transitions); j++ {
+				if allowed(n.pre[transitions[i]], n.pre[transitions[j]]) {
+					continue
+				}
+
+				res = append(res, Conflict{Place: place, Transitions: []string{transitions[i], transitions[j]}})
+			}
+		}
+	}
+
+	return res
+}
+
+// IsWorkflowNet returns true if config is a workflow net.
+// The start place is without input transitions, the config has one terminal place without output transitions
+// and each place and transition is on a path from the start to the terminal place.
+func IsWorkflowNet(c cfg.Interface) bool {
+	terminals := cfg.GetTerminalPlaces(c)
+	if len(terminals) != 1 {
+		return false
+	}
+
+	var terminal string
+	for place := range terminals {
+		terminal = place
+	}
+
+	n := newNet(c)
+	start := c.GetStart().GetID()
+
+	if len(n.placePre[start]) > 0 || len(n.placePost[terminal]) > 0 {
+		return false
+	}
+
+	forward := n.reach(start, n.placePost, n.post)
+	backward := n.reach(terminal, n.placePre, n.pre)
+
+	for _, place := range n.places {
+		if !forward.places[place] || !backward.places[place] {
+			return false
+		}
+	}
+
+	for _, transition := range n.transitions {
+		if !forward.transitions[transition] || !backward.transitions[transition] {
+			return false
+		}
+	}
+
+	return true
+}
+
+type reachable struct {
+	places      map[string]bool
+	transitions map[string]bool
+}
+
+// reach returns places and transitions reachable from the place by the arcs.
+// Use output arcs for search forward and input arcs for search backward.
+func (n *net) reach(place string, placeArcs map[string][]string, transitionArcs map[string][]string) reachable {
+	res := reachable{places: make(map[string]bool), transitions: make(map[string]bool)}
+	queue := []string{place}
+	res.places[place] = true
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, transition := range placeArcs[current] {
+			if res.transitions[transition] {
+				continue
+			}
+
+			res.transitions[transition] = true
+
+			for _, next := range transitionArcs[transition] {
+				if !res.places[next] {
+					res.places[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,133 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/andrskom/gowfnet/cfg"
+)
+
+func TestClassify_ExpectedClasses(t *testing.T) {
+	type data struct {
+		cfg      cfg.Interface
+		expected Classes
+	}
+
+	dp := map[string]data{
+		"sequence": {
+			cfg: cfg.Minimal{
+				Start:  "start",
+				Finish: "finish",
+				Places: []cfg.StringID{"start", "a", "finish"},
+				Transitions: cfg.MinimalTransitionRegistry{
+					"t1": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"a"}},
+					"t2": {From: []cfg.StringID{"a"}, To: []cfg.StringID{"finish"}},
+				},
+			},
+			expected: Classes{StateMachine: true, FreeChoice: true, ExtendedFreeChoice: true, WorkflowNet: true},
+		},
+		"parallel": {
+			cfg: cfg.Minimal{
+				Start:  "start",
+				Finish: "finish",
+				Places: []cfg.StringID{"start", "a", "b", "finish"},
+				Transitions: cfg.MinimalTransitionRegistry{
+					"split": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"a", "b"}},
+					"join":  {From: []cfg.StringID{"a", "b"}, To: []cfg.StringID{"finish"}},
+				},
+			},
+			expected: Classes{FreeChoice: true, ExtendedFreeChoice: true, WorkflowNet: true},
+		},
+		"cycle": {
+			cfg: cfg.Minimal{
+				Start:  "a",
+				Finish: "b",
+				Places: []cfg.StringID{"a", "b"},
+				Transitions: cfg.MinimalTransitionRegistry{
+					"t1": {From: []cfg.StringID{"a"}, To: []cfg.StringID{"b"}},
+					"t2": {From: []cfg.StringID{"b"}, To: []cfg.StringID{"a"}},
+				},
+			},
+			expected: Classes{StateMachine: true, MarkedGraph: true, FreeChoice: true, ExtendedFreeChoice: true},
+		},
+		"extended free-choice": {
+			cfg: cfg.Minimal{
+				Start:  "start",
+				Finish: "finish",
+				Places: []cfg.StringID{"start", "a", "b", "finish"},
+				Transitions: cfg.MinimalTransitionRegistry{
+					"split": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"a", "b"}},
+					"t1":    {From: []cfg.StringID{"a", "b"}, To: []cfg.StringID{"finish"}},
+					"t2":    {From: []cfg.StringID{"a", "b"}, To: []cfg.StringID{"finish"}},
+				},
+			},
+			expected: Classes{ExtendedFreeChoice: true, WorkflowNet: true},
+		},
+		"outcomes": {
+			cfg: cfg.Minimal{
+				Start:    "start",
+				Places:   []cfg.StringID{"start", "approved", "rejected"},
+				Outcomes: map[string]cfg.StringID{"approved": "approved", "rejected": "rejected"},
+				Transitions: cfg.MinimalTransitionRegistry{
+					"approve": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"approved"}},
+					"reject":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"rejected"}},
+				},
+			},
+			expected: Classes{StateMachine: true, FreeChoice: true, ExtendedFreeChoice: true},
+		},
+		"dead place": {
+			cfg: cfg.Minimal{
+				Start:  "start",
+				Finish: "finish",
+				Places: []cfg.StringID{"start", "dead", "finish"},
+				Transitions: cfg.MinimalTransitionRegistry{
+					"t1": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}},
+					"t2": {From: []cfg.StringID{"dead"}, To: []cfg.StringID{"finish"}},
+				},
+			},
+			expected: Classes{StateMachine: true, FreeChoice: true, ExtendedFreeChoice: true},
+		},
+	}
+
+	for name, d := range dp {
+		d := d
+
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, d.expected, Classify(d.cfg))
+		})
+	}
+}
+
+func TestFreeChoiceConflicts_ExpectedConflicts(t *testing.T) {
+	c := cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "a", "b", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"split": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"a", "b"}},
+			"t1":    {From: []cfg.StringID{"a"}, To: []cfg.StringID{"finish"}},
+			"t2":    {From: []cfg.StringID{"a", "b"}, To: []cfg.StringID{"finish"}},
+		},
+	}
+
+	expected := []Conflict{{Place: "a", Transitions: []string{"t1", "t2"}}}
+
+	assert.Equal(t, expected, FreeChoiceConflicts(c))
+	assert.Equal(t, expected, ExtendedFreeChoiceConflicts(c))
+}
+
+func TestNonStateMachineTransitions_ExpectedTransitions(t *testing.T) {
+	c := cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "a", "b", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"split": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"a", "b"}},
+			"join":  {From: []cfg.StringID{"a", "b"}, To: []cfg.StringID{"finish"}},
+		},
+	}
+
+	assert.Equal(t, []string{"join", "split"}, NonStateMachineTransitions(c))
+	assert.Equal(t, []string{"finish", "start"}, NonMarkedGraphPlaces(c))
+}
@@ -0,0 +1,309 @@
+package analysis
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/andrskom/gowfnet/cfg"
+)
+
+// IncidenceMatrix of config, rows are places and columns are transitions.
+// Value is the number of tokens put into the place by the transition minus the number of taken tokens.
+type IncidenceMatrix struct {
+	Places      []string `json:"places"`
+	Transitions []string `json:"transitions"`
+	Values      [][]int  `json:"values"`
+}
+
+// BuildIncidenceMatrix of config, places and transitions are sorted by id.
+func BuildIncidenceMatrix(c cfg.Interface) *IncidenceMatrix {
+	return newNet(c).incidenceMatrix()
+}
+
+func (n *net) incidenceMatrix() *IncidenceMatrix {
+	placeIndexes := make(map[string]int, len(n.places))
+	for i, place := range n.places {
+		placeIndexes[place] = i
+	}
+
+	res := &IncidenceMatrix{
+		Places:      n.places,
+		Transitions: n.transitions,
+		Values:      make([][]int, len(n.places)),
+	}
+
+	for i := range res.Values {
+		res.Values[i] = make([]int, len(n.transitions))
+	}
+
+	for j, transition := range n.transitions {
+		for _, place := range n.pre[transition] {
+			if i, ok := placeIndexes[place]; ok {
+				res.Values[i][j]--
+			}
+		}
+
+		for _, place := range n.post[transition] {
+			if i, ok := placeIndexes[place]; ok {
+				res.Values[i][j]++
+			}
+		}
+	}
+
+	return res
+}
+
+// Get value for the place and the transition, returns 0 for unknown ids.
+func (m *IncidenceMatrix) Get(place string, transition string) int {
+	for i := range m.Places {
+		if m.Places[i] != place {
+			continue
+		}
+
+		for j := range m.Transitions {
+			if m.Transitions[j] == transition {
+				return m.Values[i][j]
+			}
+		}
+	}
+
+	return 0
+}
+
+// Invariant is a weight vector of places or transitions, it contains only positive weights.
+//
+// A P-invariant keeps the weighted sum of tokens in its places for each firing.
+// A T-invariant is a multiset of firings which reproduces the marking.
+type Invariant map[string]int
+
+// GetSupport returns sorted ids with positive weight.
+func (i Invariant) GetSupport() []string {
+	res := make([]string, 0, len(i))
+	for id := range i {
+		res = append(res, id)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// PInvariants returns minimal semi-positive P-invariants of config by the Farkas algorithm.
+// The number of invariants can be exponential in the size of the net.
+func PInvariants(c cfg.Interface) []Invariant {
+	m := BuildIncidenceMatrix(c)
+
+	return farkas(m.Places, m.Values)
+}
+
+// TInvariants returns minimal semi-positive T-invariants of config by the Farkas algorithm.
+// The number of invariants can be exponential in the size of the net.
+func TInvariants(c cfg.Interface) []Invariant {
+	m := BuildIncidenceMatrix(c)
+
+	return farkas(m.Transitions, transpose(m.Values, len(m.Transitions)))
+}
+
+// IsCoveredByPInvariants returns true if each place is in the support of a P-invariant.
+// A covered net is structurally bounded, it is bounded for any initial marking.
+func IsCoveredByPInvariants(c cfg.Interface) bool {
+	return len(NotCoveredPlaces(c)) == 0
+}
+
+// NotCoveredPlaces returns sorted ids of places which are not in the support of any P-invariant.
+func NotCoveredPlaces(c cfg.Interface) []string {
+	m := BuildIncidenceMatrix(c)
+	covered := make(map[string]struct{})
+
+	for _, invariant := range farkas(m.Places, m.Values) {
+		for id := range invariant {
+			covered[id] = struct{}{}
+		}
+	}
+
+	res := make([]string, 0)
+
+	for _, place := range m.Places {
+		if _, ok := covered[place]; !ok {
+			res = append(res, place)
+		}
+	}
+
+	return res
+}
+
+// farkasRow is a row of the Farkas algorithm, values are the rest of the matrix and weights are the identity part.
+type farkasRow struct {
+	values  []int
+	weights []int
+}
+
+// farkas returns minimal semi-positive solutions y of y * matrix = 0, rows of the matrix are ids.
+func farkas(ids []string, matrix [][]int) []Invariant {
+	rows := make([]farkasRow, 0, len(ids))
+
+	for i := range ids {
+		weights := make([]int, len(ids))
+		weights[i] = 1
+
+		rows = append(rows, farkasRow{values: append([]int(nil), matrix[i]...), weights: weights})
+	}
+
+	columns := 0
+	if len(matrix) > 0 {
+		columns = len(matrix[0])
+	}
+
+	for j := 0; j < columns; j++ {
+		next := make([]farkasRow, 0, len(rows))
+
+		for _, row := range rows {
+			if row.values[j] == 0 {
+				next = append(next, row)
+			}
+		}
+
+		for _, pos := range rows {
+			if pos.values[j] <= 0 {
+				continue
+			}
+
+			for _, neg := range rows {
+				if neg.values[j] >= 0 {
+					continue
+				}
+
+				next = append(next, combineRows(pos, -neg.values[j], neg, pos.values[j]))
+			}
+		}
+
+		rows = removeNotMinimalRows(next)
+	}
+
+	res := make([]Invariant, 0, len(rows))
+
+	for _, row := range rows {
+		invariant := make(Invariant)
+
+		for i, weight := range row.weights {
+			if weight > 0 {
+				invariant[ids[i]] = weight
+			}
+		}
+
+		res = append(res, invariant)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return invariantKey(res[i]) < invariantKey(res[j])
+	})
+
+	return res
+}
+
+func combineRows(a farkasRow, aFactor int, b farkasRow, bFactor int) farkasRow {
+	res := farkasRow{values: make([]int, len(a.values)), weights: make([]int, len(a.weights))}
+	divisor := 0
+
+	for i := range a.values {
+		res.values[i] = aFactor*a.values[i] + bFactor*b.values[i]
+		divisor = gcd(divisor, res.values[i])
+	}
+
+	for i := range a.weights {
+		res.weights[i] = aFactor*a.weights[i] + bFactor*b.weights[i]
+		divisor = gcd(divisor, res.weights[i])
+	}
+
+	if divisor > 1 {
+		for i := range res.values {
+			res.values[i] /= divisor
+		}
+
+		for i := range res.weights {
+			res.weights[i] /= divisor
+		}
+	}
+
+	return res
+}
+
+// removeNotMinimalRows removes rows with the support of weights which contains the support of another row.
+// Only one of rows with the same support is kept.
+func removeNotMinimalRows(rows []farkasRow) []farkasRow {
+	res := make([]farkasRow, 0, len(rows))
+
+	for i := range rows {
+		minimal := true
+
+		for j := range rows {
+			if i == j || !isSupportSubset(rows[j].weights, rows[i].weights) {
+				continue
+			}
+
+			if !isSupportSubset(rows[i].weights, rows[j].weights) || j < i {
+				minimal = false
+
+				break
+			}
+		}
+
+		if minimal {
+			res = append(res, rows[i])
+		}
+	}
+
+	return res
+}
+
+// isSupportSubset returns true if the support of a is a subset of the support of b.
+func isSupportSubset(a []int, b []int) bool {
+	for i := range a {
+		if a[i] != 0 && b[i] == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func transpose(matrix [][]int, columns int) [][]int {
+	res := make([][]int, columns)
+
+	for j := range res {
+		res[j] = make([]int, len(matrix))
+
+		for i := range matrix {
+			res[j][i] = matrix[i][j]
+		}
+	}
+
+	return res
+}
+
+func invariantKey(invariant Invariant) string {
+	parts := make([]string, 0, len(invariant))
+
+	for _, id := range invariant.GetSupport() {
+		parts = append(parts, id+":"+strconv.Itoa(invariant[id]))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+func gcd(a int, b int) int {
+	if a < 0 {
+		a = -a
+	}
+
+	if b < 0 {
+		b = -b
+	}
+
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
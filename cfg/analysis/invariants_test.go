@@ -0,0 +1,147 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/andrskom/gowfnet/cfg"
+)
+
+func TestBuildIncidenceMatrix_ExpectedMatrix(t *testing.T) {
+	m := BuildIncidenceMatrix(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "a", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"t1": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"a"}},
+			"t2": {From: []cfg.StringID{"a"}, To: []cfg.StringID{"a", "finish"}},
+		},
+	})
+
+	assert.Equal(
+		t,
+		&IncidenceMatrix{
+			Places:      []string{"a", "finish", "start"},
+			Transitions: []string{"t1", "t2"},
+			Values: [][]int{
+				{1, 0},
+				{0, 1},
+				{-1, 0},
+			},
+		},
+		m,
+	)
+	assert.Equal(t, -1, m.Get("start", "t1"))
+	assert.Equal(t, 0, m.Get("unknown", "t1"))
+}
+
+func TestInvariants_ExpectedInvariants(t *testing.T) {
+	type data struct {
+		cfg         cfg.Interface
+		pInvariants []Invariant
+		tInvariants []Invariant
+		notCovered  []string
+	}
+
+	dp := map[string]data{
+		"sequence": {
+			cfg: cfg.Minimal{
+				Start:  "start",
+				Finish: "finish",
+				Places: []cfg.StringID{"start", "a", "finish"},
+				Transitions: cfg.MinimalTransitionRegistry{
+					"t1": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"a"}},
+					"t2": {From: []cfg.StringID{"a"}, To: []cfg.StringID{"finish"}},
+				},
+			},
+			pInvariants: []Invariant{{"a": 1, "finish": 1, "start": 1}},
+			tInvariants: []Invariant{},
+			notCovered:  []string{},
+		},
+		"parallel": {
+			cfg: cfg.Minimal{
+				Start:  "start",
+				Finish: "finish",
+				Places: []cfg.StringID{"start", "a", "b", "finish"},
+				Transitions: cfg.MinimalTransitionRegistry{
+					"split": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"a", "b"}},
+					"join":  {From: []cfg.StringID{"a", "b"}, To: []cfg.StringID{"finish"}},
+				},
+			},
+			pInvariants: []Invariant{{"a": 1, "finish": 1, "start": 1}, {"b": 1, "finish": 1, "start": 1}},
+			tInvariants: []Invariant{},
+			notCovered:  []string{},
+		},
+		"rework loop": {
+			cfg: cfg.Minimal{
+				Start:  "review",
+				Finish: "finish",
+				Places: []cfg.StringID{"review", "changes", "finish"},
+				Transitions: cfg.MinimalTransitionRegistry{
+					"requestChanges": {From: []cfg.StringID{"review"}, To: []cfg.StringID{"changes"}},
+					"resubmit":       {From: []cfg.StringID{"changes"}, To: []cfg.StringID{"review"}},
+					"approve":        {From: []cfg.StringID{"review"}, To: []cfg.StringID{"finish"}},
+				},
+			},
+			pInvariants: []Invariant{{"changes": 1, "finish": 1, "review": 1}},
+			tInvariants: []Invariant{{"requestChanges": 1, "resubmit": 1}},
+			notCovered:  []string{},
+		},
+		"generator": {
+			cfg: cfg.Minimal{
+				Start:  "a",
+				Finish: "b",
+				Places: []cfg.StringID{"a", "b"},
+				Transitions: cfg.MinimalTransitionRegistry{
+					"t": {From: []cfg.StringID{"a"}, To: []cfg.StringID{"a", "b"}},
+				},
+			},
+			pInvariants: []Invariant{{"a": 1}},
+			tInvariants: []Invariant{},
+			notCovered:  []string{"b"},
+		},
+		"weighted": {
+			cfg: cfg.Minimal{
+				Start:  "a",
+				Finish: "c",
+				Places: []cfg.StringID{"a", "b", "c"},
+				Transitions: cfg.MinimalTransitionRegistry{
+					"split": {From: []cfg.StringID{"a"}, To: []cfg.StringID{"b", "c"}},
+					"join":  {From: []cfg.StringID{"b", "c"}, To: []cfg.StringID{"a"}},
+				},
+			},
+			pInvariants: []Invariant{{"a": 1, "b": 1}, {"a": 1, "c": 1}},
+			tInvariants: []Invariant{{"join": 1, "split": 1}},
+			notCovered:  []string{},
+		},
+	}
+
+	for name, d := range dp {
+		d := d
+
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, d.pInvariants, PInvariants(d.cfg))
+			assert.Equal(t, d.tInvariants, TInvariants(d.cfg))
+			assert.Equal(t, d.notCovered, NotCoveredPlaces(d.cfg))
+			assert.Equal(t, len(d.notCovered) == 0, IsCoveredByPInvariants(d.cfg))
+		})
+	}
+}
+
+func TestInvariant_GetSupport(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, Invariant{"b": 2, "a": 1}.GetSupport())
+}
+
+func TestCombineRows_DividedByGCD(t *testing.T) {
+	assert.Equal(
+		t,
+		farkasRow{values: []int{0, 2}, weights: []int{1, 1}},
+		combineRows(
+			farkasRow{values: []int{2, 2}, weights: []int{1, 0}},
+			2,
+			farkasRow{values: []int{-2, 0}, weights: []int{0, 1}},
+			2,
+		),
+	)
+}
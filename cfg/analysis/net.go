@@ -0,0 +1,98 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/andrskom/gowfnet/cfg"
+)
+
+// net is a structure of config for analysis.
+// Input places of a transition are its from places, output places are its to places.
+// Error places and cancellation regions are not a part of the structure.
+type net struct {
+	places      []string
+	transitions []string
+	pre         map[string][]string // transition id -> input places
+	post        map[string][]string // transition id -> output places
+	placePre    map[string][]string // place id -> input transitions
+	placePost   map[string][]string // place id -> output transitions
+}
+
+func newNet(c cfg.Interface) *net {
+	res := &net{
+		places:      make([]string, 0, len(c.GetPlaces())),
+		transitions: make([]string, 0, len(c.GetTransitions().GetAsMap())),
+		pre:         make(map[string][]string),
+		post:        make(map[string][]string),
+		placePre:    make(map[string][]string),
+		placePost:   make(map[string][]string),
+	}
+
+	seen := make(map[string]struct{})
+
+	for _, place := range c.GetPlaces() {
+		if _, ok := seen[place.GetID()]; ok {
+			continue
+		}
+
+		seen[place.GetID()] = struct{}{}
+		res.places = append(res.places, place.GetID())
+	}
+
+	sort.Strings(res.places)
+
+	for id := range c.GetTransitions().GetAsMap() {
+		res.transitions = append(res.transitions, id)
+	}
+
+	sort.Strings(res.transitions)
+
+	for _, id := range res.transitions {
+		transition := c.GetTransitions().GetAsMap()[id]
+
+		res.pre[id] = uniqueIDs(transition.GetFrom())
+		res.post[id] = uniqueIDs(transition.GetTo())
+
+		for _, place := range res.pre[id] {
+			res.placePost[place] = append(res.placePost[place], id)
+		}
+
+		for _, place := range res.post[id] {
+			res.placePre[place] = append(res.placePre[place], id)
+		}
+	}
+
+	return res
+}
+
+func uniqueIDs(ids []cfg.IDGetter) []string {
+	res := make([]string, 0, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+
+	for _, id := range ids {
+		if _, ok := seen[id.GetID()]; ok {
+			continue
+		}
+
+		seen[id.GetID()] = struct{}{}
+		res = append(res, id.GetID())
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+func equalIDs(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,228 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/andrskom/gowfnet/cfg"
+)
+
+// shortCircuitPrefix is a prefix of ids of transitions from terminal places to the start place.
+const shortCircuitPrefix = "\x00shortCircuit."
+
+// MinimalSiphons returns minimal siphons of config, sets of places where each transition
+// putting a token into the set takes a token from the set too. An unmarked siphon stays unmarked forever.
+// Places of siphon are sorted, siphons are sorted by places.
+// The number of siphons can be exponential in the size of the net.
+func MinimalSiphons(c cfg.Interface) [][]string {
+	n := newNet(c)
+
+	return n.minimalSets(n.placePre, n.pre)
+}
+
+// MinimalTraps returns minimal traps of config, sets of places where each transition
+// taking a token from the set puts a token into the set too. A marked trap stays marked forever.
+// Places of trap are sorted, traps are sorted by places.
+// The number of traps can be exponential in the size of the net.
+func MinimalTraps(c cfg.Interface) [][]string {
+	n := newNet(c)
+
+	return n.minimalSets(n.placePost, n.post)
+}
+
+// SiphonsWithoutMarkedTrap returns minimal siphons which don't contain a trap marked in the start of the net.
+//
+// Siphons are computed for the short-circuited config, where each terminal place is connected
+// with the start place by a transition. By Commoner's theorem a free-choice short-circuited net is live
+// if each siphon contains a marked trap, so the result is empty for a sound free-choice workflow.
+func SiphonsWithoutMarkedTrap(c cfg.Interface) [][]string {
+	n := newNet(c)
+	start := c.GetStart().GetID()
+
+	n.shortCircuit(start, cfg.GetTerminalPlaces(c))
+
+	res := make([][]string, 0)
+
+	for _, siphon := range n.minimalSets(n.placePre, n.pre) {
+		trap := n.maximalTrap(siphon)
+		if _, ok := trap[start]; !ok {
+			res = append(res, siphon)
+		}
+	}
+
+	return res
+}
+
+// shortCircuit adds transitions from terminal places to the start place.
+func (n *net) shortCircuit(start string, terminals map[string]string) {
+	places := make([]string, 0, len(terminals))
+	for place := range terminals {
+		places = append(places, place)
+	}
+
+	sort.Strings(places)
+
+	for _, place := range places {
+		id := shortCircuitPrefix + place
+
+		n.transitions = append(n.transitions, id)
+		n.pre[id] = []string{place}
+		n.post[id] = []string{start}
+		n.placePost[place] = append(n.placePost[place], id)
+		n.placePre[start] = append(n.placePre[start], id)
+	}
+}
+
+// maximalTrap returns the largest trap in the set of places, it is empty if there is no trap.
+func (n *net) maximalTrap(places []string) map[string]struct{} {
+	res := make(map[string]struct{}, len(places))
+	for _, place := range places {
+		res[place] = struct{}{}
+	}
+
+	for removed := true; removed; {
+		removed = false
+
+		for _, place := range places {
+			if _, ok := res[place]; !ok {
+				continue
+			}
+
+			for _, transition := range n.placePost[place] {
+				if !intersects(n.post[transition], res) {
+					delete(res, place)
+
+					removed = true
+
+					break
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+// minimalSets returns minimal non-empty sets of places where each transition from placeArcs of a place of the set
+// has a place of the set in its transitionArcs.
+// Input arcs give siphons and output arcs give traps.
+func (n *net) minimalSets(placeArcs map[string][]string, transitionArcs map[string][]string) [][]string {
+	found := make([]map[string]struct{}, 0)
+
+	var search func(set map[string]struct{})
+
+	search = func(set map[string]struct{}) {
+		for _, f := range found {
+			if isSubset(f, set) {
+				return
+			}
+		}
+
+		transition, ok := n.findUnsatisfied(set, placeArcs, transitionArcs)
+		if !ok {
+			found = append(found, copySet(set))
+
+			return
+		}
+
+		for _, place := range transitionArcs[transition] {
+			set[place] = struct{}{}
+			search(set)
+			delete(set, place)
+		}
+	}
+
+	for _, place := range n.places {
+		search(map[string]struct{}{place: {}})
+	}
+
+	res := make([][]string, 0, len(found))
+
+	for i := range found {
+		minimal := true
+
+		for j := range found {
+			if i != j && isSubset(found[j], found[i]) && (len(found[j]) < len(found[i]) || j < i) {
+				minimal = false
+
+				break
+			}
+		}
+
+		if minimal {
+			res = append(res, sortedSet(found[i]))
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return strings.Join(res[i], ",") < strings.Join(res[j], ",")
+	})
+
+	return res
+}
+
+// findUnsatisfied returns a transition of the set which doesn't have a place of the set in its arcs.
+func (n *net) findUnsatisfied(
+	set map[string]struct{},
+	placeArcs map[string][]string,
+	transitionArcs map[string][]string,
+) (string, bool) {
+	for _, place := range n.places {
+		if _, ok := set[place]; !ok {
+			continue
+		}
+
+		for _, transition := range placeArcs[place] {
+			if !intersects(transitionArcs[transition], set) {
+				return transition, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func intersects(places []string, set map[string]struct{}) bool {
+	for _, place := range places {
+		if _, ok := set[place]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSubset returns true if a is a subset of b.
+func isSubset(a map[string]struct{}, b map[string]struct{}) bool {
+	if len(a) > len(b) {
+		return false
+	}
+
+	for place := range a {
+		if _, ok := b[place]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func copySet(set map[string]struct{}) map[string]struct{} {
+	res := make(map[string]struct{}, len(set))
+	for place := range set {
+		res[place] = struct{}{}
+	}
+
+	return res
+}
+
+func sortedSet(set map[string]struct{}) []string {
+	res := make([]string, 0, len(set))
+	for place := range set {
+		res = append(res, place)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
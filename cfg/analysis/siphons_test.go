@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/andrskom/gowfnet/cfg"
+)
+
+var sequenceCfg = cfg.Minimal{ // nolint:gochecknoglobals
+	Start:  "start",
+	Finish: "finish",
+	Places: []cfg.StringID{"start", "a", "finish"},
+	Transitions: cfg.MinimalTransitionRegistry{
+		"t1": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"a"}},
+		"t2": {From: []cfg.StringID{"a"}, To: []cfg.StringID{"finish"}},
+	},
+}
+
+var unmarkedSiphonCfg = cfg.Minimal{ // nolint:gochecknoglobals
+	Start:  "start",
+	Finish: "finish",
+	Places: []cfg.StringID{"start", "a", "b", "x", "finish"},
+	Transitions: cfg.MinimalTransitionRegistry{
+		"split": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"a", "b"}},
+		"join":  {From: []cfg.StringID{"a", "b", "x"}, To: []cfg.StringID{"finish"}},
+	},
+}
+
+func TestMinimalSiphons_ExpectedSiphons(t *testing.T) {
+	assert.Equal(t, [][]string{{"start"}}, MinimalSiphons(sequenceCfg))
+	assert.Equal(t, [][]string{{"start"}, {"x"}}, MinimalSiphons(unmarkedSiphonCfg))
+}
+
+func TestMinimalTraps_ExpectedTraps(t *testing.T) {
+	assert.Equal(t, [][]string{{"finish"}}, MinimalTraps(sequenceCfg))
+	assert.Equal(t, [][]string{{"finish"}}, MinimalTraps(unmarkedSiphonCfg))
+}
+
+func TestMinimalSiphons_Cycle_OneSiphonAndTrap(t *testing.T) {
+	c := cfg.Minimal{
+		Start:  "a",
+		Finish: "b",
+		Places: []cfg.StringID{"a", "b"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"t1": {From: []cfg.StringID{"a"}, To: []cfg.StringID{"b"}},
+			"t2": {From: []cfg.StringID{"b"}, To: []cfg.StringID{"a"}},
+		},
+	}
+
+	assert.Equal(t, [][]string{{"a", "b"}}, MinimalSiphons(c))
+	assert.Equal(t, [][]string{{"a", "b"}}, MinimalTraps(c))
+}
+
+func TestSiphonsWithoutMarkedTrap_ExpectedSiphons(t *testing.T) {
+	assert.Equal(t, [][]string{}, SiphonsWithoutMarkedTrap(sequenceCfg))
+	assert.Equal(t, [][]string{{"x"}}, SiphonsWithoutMarkedTrap(unmarkedSiphonCfg))
+}
+
+func TestSiphonsWithoutMarkedTrap_Outcomes_TerminalsAreShortCircuited(t *testing.T) {
+	c := cfg.Minimal{
+		Start:    "start",
+		Places:   []cfg.StringID{"start", "approved", "rejected"},
+		Outcomes: map[string]cfg.StringID{"approved": "approved", "rejected": "rejected"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"approve": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"approved"}},
+			"reject":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"rejected"}},
+		},
+	}
+
+	assert.Equal(t, [][]string{}, SiphonsWithoutMarkedTrap(c))
+}
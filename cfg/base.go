@@ -1,5 +1,7 @@
 package cfg
 
+import "time"
+
 type IDGetter interface {
 	GetID() string
 }
@@ -9,6 +11,45 @@ type TransitionInterface interface {
 	GetTo() []IDGetter
 }
 
+// TimedTransitionInterface is implemented by transitions which can be fired by time.
+// GetTimer returns nil if the transition isn't timed.
+type TimedTransitionInterface interface {
+	TransitionInterface
+	GetTimer() TimerInterface
+}
+
+// SubprocessTransitionInterface is implemented by transitions which can run another registered config.
+// GetSubprocess returns the name of the config in the registry or empty string if the transition isn't a subprocess.
+type SubprocessTransitionInterface interface {
+	TransitionInterface
+	GetSubprocess() string
+}
+
+// CancellingTransitionInterface is implemented by transitions with a cancellation region.
+// Firing of the transition removes tokens from places of the region.
+type CancellingTransitionInterface interface {
+	TransitionInterface
+	GetCancel() []IDGetter
+}
+
+// ReversibleTransitionInterface is implemented by transitions which can be undone.
+type ReversibleTransitionInterface interface {
+	TransitionInterface
+	IsReversible() bool
+}
+
+// ErrorPlaceTransitionInterface is implemented by transitions which route the token to an error place on failure.
+// GetErrorPlace returns nil if the error place is not set.
+type ErrorPlaceTransitionInterface interface {
+	TransitionInterface
+	GetErrorPlace() IDGetter
+}
+
+type TimerInterface interface {
+	GetType() TimerType
+	GetDuration() time.Duration
+}
+
 type TransitionRegistryInterface interface {
 	GetAsMap() map[string]TransitionInterface
 	GetByID(transitionID IDGetter) (TransitionInterface, error)
@@ -20,3 +61,10 @@ type Interface interface {
 	GetPlaces() []IDGetter
 	GetTransitions() TransitionRegistryInterface
 }
+
+// OutcomesInterface is implemented by configs with many terminal places.
+// GetOutcomes returns terminal places by names of outcomes, the finish place is terminal too.
+type OutcomesInterface interface {
+	Interface
+	GetOutcomes() map[string]IDGetter
+}
@@ -0,0 +1,259 @@
+package compose
+
+import (
+	"sort"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/cfg/validator"
+)
+
+// Separator between prefix of fragment and id of place or transition.
+const Separator = "."
+
+// Rules of issues of composition conflicts.
+const (
+	RuleFusion     validator.Rule = "compose.fusion"
+	RuleDuplicated validator.Rule = "compose.duplicated"
+)
+
+// Composer builds one config from fragments.
+//
+// Ids of places and transitions of a fragment are prefixed to avoid collisions,
+// places of different fragments are connected by fusion into one place.
+type Composer struct {
+	fragments []fragment
+	fusions   map[string]string
+	outcomes  map[string]cfg.StringID
+	validator validator.Validator
+}
+
+type fragment struct {
+	prefix string
+	config cfg.Interface
+}
+
+// New init composer which validates result by all validators.
+func New() *Composer {
+	return NewWithValidator(validator.NewCombinedWithAllValidators())
+}
+
+// NewWithValidator init composer which validates result by the validator.
+func NewWithValidator(v validator.Validator) *Composer {
+	return &Composer{
+		fragments: make([]fragment, 0),
+		fusions:   make(map[string]string),
+		outcomes:  make(map[string]cfg.StringID),
+		validator: v,
+	}
+}
+
+// Add fragment to composition.
+// Ids of places and transitions of the fragment are prefixed by "prefix.", empty prefix keeps ids as is.
+func (c *Composer) Add(prefix string, config cfg.Interface) *Composer {
+	c.fragments = append(c.fragments, fragment{prefix: prefix, config: config})
+
+	return c
+}
+
+// Fuse places into one place with the id.
+// Use prefixed ids of places, e.g. "review.finish".
+func (c *Composer) Fuse(placeID string, places ...string) *Composer {
+	for _, place := range places {
+		c.fusions[place] = placeID
+	}
+
+	return c
+}
+
+// Outcome adds the outcome with the terminal place to the result.
+// Use the id of the place after prefixing and fusion.
+func (c *Composer) Outcome(name string, placeID string) *Composer {
+	c.outcomes[name] = cfg.StringID(placeID)
+
+	return c
+}
+
+// Build config with start and finish places, use ids after prefixing and fusion.
+// Conflicts of composition and errors of validation are returned as *validator.Error.
+func (c *Composer) Build(start string, finish string) (cfg.Minimal, error) {
+	res := cfg.Minimal{
+		Start:       cfg.StringID(start),
+		Finish:      cfg.StringID(finish),
+		Places:      make([]cfg.StringID, 0),
+		Transitions: make(cfg.MinimalTransitionRegistry),
+	}
+
+	if len(c.outcomes) > 0 {
+		res.Outcomes = c.outcomes
+	}
+
+	vErr := validator.NewError()
+	c.buildPlaces(&res, vErr)
+	c.buildTransitions(&res, vErr)
+
+	if vErr.Has() {
+		return cfg.Minimal{}, vErr
+	}
+
+	if err := c.validator.Validate(res); err != nil {
+		return cfg.Minimal{}, err
+	}
+
+	return res, nil
+}
+
+func (c *Composer) buildPlaces(res *cfg.Minimal, vErr *validator.Error) {
+	targets := make(map[string]struct{})
+	for _, fused := range c.fusions {
+		targets[fused] = struct{}{}
+	}
+
+	foundFusions := make(map[string]struct{})
+	added := make(map[string]struct{})
+
+	for _, f := range c.fragments {
+		for _, place := range f.config.GetPlaces() {
+			prefixed := prefixID(f.prefix, place.GetID())
+			id := c.renamePlace(f.prefix, place.GetID())
+
+			_, isFused := c.fusions[prefixed]
+			if isFused {
+				foundFusions[prefixed] = struct{}{}
+			}
+
+			if _, isTarget := targets[id]; isTarget && !isFused {
+				vErr.Add(validator.NewIssuef(
+					RuleFusion,
+					"place with id '%s' conflicts with fused place",
+					prefixed,
+				).WithPlaces(prefixed))
+
+				continue
+			}
+
+			if _, ok := added[id]; ok {
+				if !isFused {
+					vErr.Add(validator.NewIssuef(
+						RuleDuplicated,
+						"place with id '%s' is duplicated in fragments, use prefix or fusion",
+						prefixed,
+					).WithPlaces(prefixed))
+				}
+
+				continue
+			}
+
+			added[id] = struct{}{}
+			res.Places = append(res.Places, cfg.StringID(id))
+		}
+	}
+
+	notFound := make([]string, 0)
+
+	for place := range c.fusions {
+		if _, ok := foundFusions[place]; !ok {
+			notFound = append(notFound, place)
+		}
+	}
+
+	sort.Strings(notFound)
+
+	for _, place := range notFound {
+		vErr.Add(validator.NewIssuef(
+			RuleFusion,
+			"place with id '%s' for fusion into '%s' is not found in fragments",
+			place,
+			c.fusions[place],
+		).WithPlaces(place))
+	}
+}
+
+func (c *Composer) buildTransitions(res *cfg.Minimal, vErr *validator.Error) {
+	for _, f := range c.fragments {
+		transitions := f.config.GetTransitions().GetAsMap()
+
+		ids := make([]string, 0, len(transitions))
+		for id := range transitions {
+			ids = append(ids, id)
+		}
+
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			transition := transitions[id]
+			prefixed := prefixID(f.prefix, id)
+
+			if _, ok := res.Transitions[prefixed]; ok {
+				vErr.Add(validator.NewIssuef(
+					RuleDuplicated,
+					"transition with id '%s' is duplicated in fragments",
+					prefixed,
+				).WithTransitions(prefixed))
+
+				continue
+			}
+
+			res.Transitions[prefixed] = c.buildTransition(f.prefix, transition)
+		}
+	}
+}
+
+func (c *Composer) buildTransition(prefix string, transition cfg.TransitionInterface) cfg.MinimalTransition {
+	res := cfg.MinimalTransition{
+		From: c.renamePlaces(prefix, transition.GetFrom()),
+		To:   c.renamePlaces(prefix, transition.GetTo()),
+	}
+
+	if timed, ok := transition.(cfg.TimedTransitionInterface); ok && timed.GetTimer() != nil {
+		res.Timer = &cfg.MinimalTimer{
+			Type:     timed.GetTimer().GetType(),
+			Duration: cfg.Duration(timed.GetTimer().GetDuration()),
+		}
+	}
+
+	if sub, ok := transition.(cfg.SubprocessTransitionInterface); ok {
+		res.Subprocess = sub.GetSubprocess()
+	}
+
+	if cancelling, ok := transition.(cfg.CancellingTransitionInterface); ok && len(cancelling.GetCancel()) > 0 {
+		res.Cancel = c.renamePlaces(prefix, cancelling.GetCancel())
+	}
+
+	if errPlace := cfg.GetErrorPlace(transition); errPlace != nil {
+		res.ErrorPlace = cfg.StringID(c.renamePlace(prefix, errPlace.GetID()))
+	}
+
+	if reversible, ok := transition.(cfg.ReversibleTransitionInterface); ok {
+		res.Reversible = reversible.IsReversible()
+	}
+
+	return res
+}
+
+func (c *Composer) renamePlaces(prefix string, places []cfg.IDGetter) []cfg.StringID {
+	res := make([]cfg.StringID, 0, len(places))
+
+	for _, place := range places {
+		res = append(res, cfg.StringID(c.renamePlace(prefix, place.GetID())))
+	}
+
+	return res
+}
+
+func (c *Composer) renamePlace(prefix string, placeID string) string {
+	prefixed := prefixID(prefix, placeID)
+
+	if fused, ok := c.fusions[prefixed]; ok {
+		return fused
+	}
+
+	return prefixed
+}
+
+func prefixID(prefix string, id string) string {
+	if prefix == "" {
+		return id
+	}
+
+	return prefix + Separator + id
+}
@@ -0,0 +1,226 @@
+// nolint:gochecknoglobals
+package compose
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/cfg/validator"
+)
+
+var mainFragment = cfg.Minimal{
+	Start:  "start",
+	Finish: "finish",
+	Places: []cfg.StringID{"start", "toReview", "reviewed", "finish"},
+	Transitions: cfg.MinimalTransitionRegistry{
+		"submit": {
+			From: []cfg.StringID{"start"},
+			To:   []cfg.StringID{"toReview"},
+		},
+		"publish": {
+			From: []cfg.StringID{"reviewed"},
+			To:   []cfg.StringID{"finish"},
+		},
+	},
+}
+
+var reviewFragment = cfg.Minimal{
+	Start:  "start",
+	Finish: "finish",
+	Places: []cfg.StringID{"start", "inReview", "finish"},
+	Transitions: cfg.MinimalTransitionRegistry{
+		"take": {
+			From:  []cfg.StringID{"start"},
+			To:    []cfg.StringID{"inReview"},
+			Timer: &cfg.MinimalTimer{Type: cfg.TimerTypeDelay, Duration: cfg.Duration(time.Hour)},
+		},
+		"approve": {
+			From:       []cfg.StringID{"inReview"},
+			To:         []cfg.StringID{"finish"},
+			Reversible: true,
+		},
+	},
+}
+
+func TestComposer_Build_FusedFragments_ExpectedCfg(t *testing.T) {
+	res, err := New().
+		Add("main", mainFragment).
+		Add("review", reviewFragment).
+		Fuse("toReview", "main.toReview", "review.start").
+		Fuse("reviewed", "main.reviewed", "review.finish").
+		Build("main.start", "main.finish")
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		cfg.Minimal{
+			Start:  "main.start",
+			Finish: "main.finish",
+			Places: []cfg.StringID{"main.start", "toReview", "reviewed", "main.finish", "review.inReview"},
+			Transitions: cfg.MinimalTransitionRegistry{
+				"main.submit": {
+					From: []cfg.StringID{"main.start"},
+					To:   []cfg.StringID{"toReview"},
+				},
+				"main.publish": {
+					From: []cfg.StringID{"reviewed"},
+					To:   []cfg.StringID{"main.finish"},
+				},
+				"review.take": {
+					From:  []cfg.StringID{"toReview"},
+					To:    []cfg.StringID{"review.inReview"},
+					Timer: &cfg.MinimalTimer{Type: cfg.TimerTypeDelay, Duration: cfg.Duration(time.Hour)},
+				},
+				"review.approve": {
+					From:       []cfg.StringID{"review.inReview"},
+					To:         []cfg.StringID{"reviewed"},
+					Reversible: true,
+				},
+			},
+		},
+		res,
+	)
+}
+
+func TestComposer_Build_WithoutPrefix_DuplicatesAreReported(t *testing.T) {
+	_, err := New().
+		Add("", reviewFragment).
+		Add("", reviewFragment).
+		Build("start", "finish")
+
+	require.IsType(t, &validator.Error{}, err)
+	assert.Equal(
+		t,
+		[]string{
+			"place with id 'start' is duplicated in fragments, use prefix or fusion",
+			"place with id 'inReview' is duplicated in fragments, use prefix or fusion",
+			"place with id 'finish' is duplicated in fragments, use prefix or fusion",
+			"transition with id 'approve' is duplicated in fragments",
+			"transition with id 'take' is duplicated in fragments",
+		},
+		err.(*validator.Error).Get(),
+	)
+	assert.Equal(
+		t,
+		validator.NewIssuef(RuleDuplicated, "transition with id 'take' is duplicated in fragments").WithTransitions("take"),
+		err.(*validator.Error).GetIssues()[4],
+	)
+}
+
+func TestComposer_Build_FusionConflicts_ExpectedErr(t *testing.T) {
+	_, err := New().
+		Add("main", mainFragment).
+		Fuse("main.start", "main.toReview").
+		Fuse("x", "review.start").
+		Build("main.start", "main.finish")
+
+	require.IsType(t, &validator.Error{}, err)
+	assert.Equal(
+		t,
+		[]string{
+			"place with id 'main.start' conflicts with fused place",
+			"place with id 'review.start' for fusion into 'x' is not found in fragments",
+		},
+		err.(*validator.Error).Get(),
+	)
+	assert.Equal(
+		t,
+		validator.NewIssuef(
+			RuleFusion,
+			"place with id 'review.start' for fusion into 'x' is not found in fragments",
+		).WithPlaces("review.start"),
+		err.(*validator.Error).GetIssues()[1],
+	)
+}
+
+func TestComposer_Build_NotValidResult_ValidatorErr(t *testing.T) {
+	_, err := New().
+		Add("main", mainFragment).
+		Add("review", reviewFragment).
+		Build("main.start", "main.finish")
+
+	require.IsType(t, &validator.Error{}, err)
+	assert.Contains(t, err.(*validator.Error).Get(), "place with id 'main.reviewed' is dead place")
+}
+
+type validatorMock struct {
+	err error
+	cfg cfg.Interface
+}
+
+func (v *validatorMock) Validate(c cfg.Interface) error {
+	v.cfg = c
+
+	return v.err
+}
+
+func TestNewWithValidator_ValidatorIsUsed(t *testing.T) {
+	eErr := errors.New("a")
+	v := &validatorMock{err: eErr}
+
+	_, err := NewWithValidator(v).Add("main", mainFragment).Build("main.start", "main.finish")
+	assert.Same(t, eErr, err)
+	assert.Equal(t, cfg.StringID("main.start"), v.cfg.GetStart())
+}
+
+func TestComposer_Build_CancelPlacesAreRenamed(t *testing.T) {
+	fragment := cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "waiting", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"begin": {
+				From: []cfg.StringID{"start"},
+				To:   []cfg.StringID{"waiting", "finish"},
+			},
+			"abort": {
+				From:   []cfg.StringID{"waiting"},
+				To:     []cfg.StringID{"finish"},
+				Cancel: []cfg.StringID{"waiting", "finish"},
+			},
+		},
+	}
+
+	res, err := NewWithValidator(&validatorMock{}).
+		Add("main", fragment).
+		Fuse("end", "main.finish").
+		Build("main.start", "end")
+
+	require.NoError(t, err)
+	assert.Equal(t, []cfg.StringID{"main.waiting", "end"}, res.Transitions["main.abort"].Cancel)
+	assert.Nil(t, res.Transitions["main.begin"].Cancel)
+}
+
+func TestComposer_Build_WithOutcomes_ExpectedOutcomes(t *testing.T) {
+	res, err := New().
+		Add("review", reviewFragment).
+		Outcome("approved", "review.finish").
+		Build("review.start", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]cfg.StringID{"approved": "review.finish"}, res.Outcomes)
+	assert.Equal(t, cfg.StringID(""), res.Finish)
+}
+
+func TestComposer_Build_ErrorPlaceIsRenamed(t *testing.T) {
+	fragment := cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "failed", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"send":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}, ErrorPlace: "failed"},
+			"retry": {From: []cfg.StringID{"failed"}, To: []cfg.StringID{"start"}},
+		},
+	}
+
+	res, err := New().Add("main", fragment).Build("main.start", "main.finish")
+
+	require.NoError(t, err)
+	assert.Equal(t, cfg.StringID("main.failed"), res.Transitions["main.send"].ErrorPlace)
+	assert.Equal(t, cfg.StringID(""), res.Transitions["main.retry"].ErrorPlace)
+}
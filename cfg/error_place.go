@@ -0,0 +1,11 @@
+package cfg
+
+// GetErrorPlace returns the error place of the transition or nil if the transition doesn't have it.
+func GetErrorPlace(transition TransitionInterface) IDGetter {
+	errPlaceTransition, ok := transition.(ErrorPlaceTransitionInterface)
+	if !ok {
+		return nil
+	}
+
+	return errPlaceTransition.GetErrorPlace()
+}
@@ -0,0 +1,23 @@
+package cfg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testingTransition struct{}
+
+func (t testingTransition) GetFrom() []IDGetter {
+	return nil
+}
+
+func (t testingTransition) GetTo() []IDGetter {
+	return nil
+}
+
+func TestGetErrorPlace(t *testing.T) {
+	assert.Nil(t, GetErrorPlace(testingTransition{}))
+	assert.Nil(t, GetErrorPlace(MinimalTransition{}))
+	assert.Equal(t, StringID("e"), GetErrorPlace(MinimalTransition{ErrorPlace: "e"}))
+}
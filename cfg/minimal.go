@@ -24,13 +24,14 @@ func (s StringID) GetID() string {
 }
 
 // Minimal is an implementation of Interface.
-// This contains only required fields.
+// This contains required fields and optional outcomes which are omitted in json if they are not set.
 // The easiest ways of setting config are via const or via json.
 type Minimal struct {
 	Start       StringID                  `json:"start"`
 	Finish      StringID                  `json:"finish"`
 	Places      []StringID                `json:"places"`
 	Transitions MinimalTransitionRegistry `json:"transitions"`
+	Outcomes    map[string]StringID       `json:"outcomes,omitempty"`
 }
 
 func (m Minimal) GetStart() IDGetter {
@@ -49,11 +50,25 @@ func (m Minimal) GetTransitions() TransitionRegistryInterface {
 	return m.Transitions
 }
 
+func (m Minimal) GetOutcomes() map[string]IDGetter {
+	res := make(map[string]IDGetter, len(m.Outcomes))
+	for name, place := range m.Outcomes {
+		res[name] = place
+	}
+
+	return res
+}
+
 // MinimalTransition is a simple implementation of TransitionInterface.
-// This contains only required fields.
+// This contains required fields and optional extensions which are omitted in json if they are not set.
 type MinimalTransition struct {
-	To   []StringID `json:"to"`
-	From []StringID `json:"from"`
+	To         []StringID    `json:"to"`
+	From       []StringID    `json:"from"`
+	Timer      *MinimalTimer `json:"timer,omitempty"`
+	Subprocess string        `json:"subprocess,omitempty"`
+	Cancel     []StringID    `json:"cancel,omitempty"`
+	Reversible bool          `json:"reversible,omitempty"`
+	ErrorPlace StringID      `json:"errorPlace,omitempty"`
 }
 
 func (m MinimalTransition) GetFrom() []IDGetter {
@@ -64,6 +79,36 @@ func (m MinimalTransition) GetTo() []IDGetter {
 	return convertSliceFromStringToInterface(m.To)
 }
 
+// GetTimer returns nil if the timer is not set.
+func (m MinimalTransition) GetTimer() TimerInterface {
+	if m.Timer == nil {
+		return nil
+	}
+
+	return *m.Timer
+}
+
+func (m MinimalTransition) GetSubprocess() string {
+	return m.Subprocess
+}
+
+func (m MinimalTransition) GetCancel() []IDGetter {
+	return convertSliceFromStringToInterface(m.Cancel)
+}
+
+func (m MinimalTransition) IsReversible() bool {
+	return m.Reversible
+}
+
+// GetErrorPlace returns nil if the error place is not set.
+func (m MinimalTransition) GetErrorPlace() IDGetter {
+	if m.ErrorPlace == "" {
+		return nil
+	}
+
+	return m.ErrorPlace
+}
+
 // MinimalTransitionRegistry is a simple implementation of TransitionRegistryInterface.
 // This contains only required fields.
 type MinimalTransitionRegistry map[string]MinimalTransition
@@ -3,6 +3,7 @@ package cfg
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -143,3 +144,40 @@ func TestMinimalTransitionRegistry_UnmarshalJSON_UnexpectedBytes_ExpectedErr(t *
 	assert.Error(t, err)
 	assert.IsType(t, &json.UnmarshalTypeError{}, err)
 }
+
+func TestMinimalTransition_GetTimer_NotSet_ReturnsNil(t *testing.T) {
+	assert.Nil(t, MinimalTransition{}.GetTimer())
+}
+
+func TestMinimalTransition_GetTimer_Set_ReturnsTimer(t *testing.T) {
+	tr := MinimalTransition{
+		Timer: &MinimalTimer{Type: TimerTypeDelay, Duration: Duration(time.Hour)},
+	}
+
+	timer := tr.GetTimer()
+	require.NotNil(t, timer)
+	assert.Equal(t, TimerTypeDelay, timer.GetType())
+	assert.Equal(t, time.Hour, timer.GetDuration())
+}
+
+func TestMinimalTransition_GetSubprocess(t *testing.T) {
+	assert.Equal(t, "review", MinimalTransition{Subprocess: "review"}.GetSubprocess())
+}
+
+func TestMinimalTransition_GetCancel(t *testing.T) {
+	assert.Equal(
+		t,
+		[]IDGetter{CreateStringID("a")},
+		MinimalTransition{Cancel: []StringID{"a"}}.GetCancel(),
+	)
+}
+
+func TestMinimalTransition_IsReversible(t *testing.T) {
+	assert.False(t, MinimalTransition{}.IsReversible())
+	assert.True(t, MinimalTransition{Reversible: true}.IsReversible())
+}
+
+func TestMinimalTransition_GetErrorPlace(t *testing.T) {
+	assert.Nil(t, MinimalTransition{}.GetErrorPlace())
+	assert.Equal(t, StringID("e"), MinimalTransition{ErrorPlace: "e"}.GetErrorPlace())
+}
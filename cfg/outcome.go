@@ -0,0 +1,23 @@
+package cfg
+
+// GetTerminalPlaces returns names of outcomes by ids of terminal places of the config.
+//
+// The finish place is terminal with empty outcome name if no outcome references it.
+// The finish place can be empty if the config has outcomes, then it is skipped.
+func GetTerminalPlaces(c Interface) map[string]string {
+	res := make(map[string]string)
+
+	outcomes, ok := c.(OutcomesInterface)
+	if ok {
+		for name, place := range outcomes.GetOutcomes() {
+			res[place.GetID()] = name
+		}
+	}
+
+	finish := c.GetFinish().GetID()
+	if _, ok := res[finish]; !ok && (finish != "" || len(res) == 0) {
+		res[finish] = ""
+	}
+
+	return res
+}
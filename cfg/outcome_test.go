@@ -0,0 +1,48 @@
+package cfg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinimal_GetOutcomes(t *testing.T) {
+	c := Minimal{Outcomes: map[string]StringID{"approved": "a"}}
+	assert.Equal(t, map[string]IDGetter{"approved": StringID("a")}, c.GetOutcomes())
+}
+
+func TestGetTerminalPlaces(t *testing.T) {
+	type data struct {
+		cfg      Interface
+		expected map[string]string
+	}
+
+	dp := map[string]data{
+		"only finish": {
+			cfg:      Minimal{Finish: "f"},
+			expected: map[string]string{"f": ""},
+		},
+		"finish and outcomes": {
+			cfg:      Minimal{Finish: "f", Outcomes: map[string]StringID{"rejected": "r"}},
+			expected: map[string]string{"f": "", "r": "rejected"},
+		},
+		"outcome of finish": {
+			cfg:      Minimal{Finish: "f", Outcomes: map[string]StringID{"approved": "f", "rejected": "r"}},
+			expected: map[string]string{"f": "approved", "r": "rejected"},
+		},
+		"outcomes without finish": {
+			cfg:      Minimal{Outcomes: map[string]StringID{"approved": "a", "rejected": "r"}},
+			expected: map[string]string{"a": "approved", "r": "rejected"},
+		},
+		"empty finish without outcomes": {
+			cfg:      Minimal{},
+			expected: map[string]string{"": ""},
+		},
+	}
+
+	for name, d := range dp {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, d.expected, GetTerminalPlaces(d.cfg))
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package cfg
+
+import "github.com/andrskom/gowfnet/state"
+
+// Sentinels of error codes of the pkg for errors.Is, see state.NewSentinel.
+//
+// nolint:gochecknoglobals
+var (
+	ErrNilCfg               = state.NewSentinel(ErrCodeNilCfg)
+	ErrCfgAlreadyRegistered = state.NewSentinel(ErrCodeCfgAlreadyRegistered)
+	ErrCfgNotRegistered     = state.NewSentinel(ErrCodeCfgNotRegistered)
+	ErrUnknownTransitionID  = state.NewSentinel(ErrCodeUnknownTransitionID)
+)
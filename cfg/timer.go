@@ -0,0 +1,55 @@
+package cfg
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TimerType describes from which moment the duration of a timer is counted.
+type TimerType string
+
+const (
+	// TimerTypeDelay is counted from the moment when the last input place of a transition was marked,
+	// so it is the time the transition was enabled.
+	TimerTypeDelay TimerType = "delay"
+	// TimerTypeDeadline is counted from the moment when the first input place of a transition was marked,
+	// so time spent waiting for other input places is included.
+	TimerTypeDeadline TimerType = "deadline"
+)
+
+// Duration is time.Duration with json representation as a string, e.g. "48h".
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+
+	res, err := time.ParseDuration(str)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(res)
+
+	return nil
+}
+
+// MinimalTimer is a simple implementation of TimerInterface.
+type MinimalTimer struct {
+	Type     TimerType `json:"type"`
+	Duration Duration  `json:"duration"`
+}
+
+func (m MinimalTimer) GetType() TimerType {
+	return m.Type
+}
+
+func (m MinimalTimer) GetDuration() time.Duration {
+	return time.Duration(m.Duration)
+}
@@ -0,0 +1,53 @@
+package cfg
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuration_Marshalling(t *testing.T) {
+	d := Duration(48 * time.Hour)
+
+	bytes, err := json.Marshal(d)
+	require.NoError(t, err)
+	assert.Equal(t, `"48h0m0s"`, string(bytes))
+
+	var res Duration
+
+	require.NoError(t, json.Unmarshal(bytes, &res))
+	assert.Equal(t, d, res)
+}
+
+func TestDuration_UnmarshalJSON_NotString_ExpectedErr(t *testing.T) {
+	var res Duration
+
+	assert.IsType(t, &json.UnmarshalTypeError{}, json.Unmarshal([]byte(`1`), &res))
+}
+
+func TestDuration_UnmarshalJSON_BadFormat_ExpectedErr(t *testing.T) {
+	var res Duration
+
+	assert.Error(t, json.Unmarshal([]byte(`"two days"`), &res))
+}
+
+func TestMinimalTransition_Timer_Unmarshalling(t *testing.T) {
+	var tr MinimalTransition
+
+	require.NoError(t, json.Unmarshal(
+		[]byte(`{"from":["a"],"to":["b"],"timer":{"type":"deadline","duration":"30m"}}`),
+		&tr,
+	))
+	assert.Equal(
+		t,
+		MinimalTransition{
+			From:  []StringID{"a"},
+			To:    []StringID{"b"},
+			Timer: &MinimalTimer{Type: TimerTypeDeadline, Duration: Duration(30 * time.Minute)},
+		},
+		tr,
+	)
+}
@@ -17,7 +17,11 @@ func (a *AllPlacesInTransitions) Validate(c cfg.Interface) error {
 
 	for _, place := range c.GetPlaces() {
 		if _, ok := m[place.GetID()]; !ok {
-			err.Addf("transitions don't use place with id '%s'", place.GetID())
+			err.Add(NewIssuef(
+				RuleAllPlacesInTransitions,
+				"transitions don't use place with id '%s'",
+				place.GetID(),
+			).WithPlaces(place.GetID()))
 		}
 	}
 
@@ -40,6 +40,6 @@ func TestAllPlacesInTransitions_Validate_NotValidCfg_ExpectedErr(t *testing.T) {
 	v := NewAllPlacesInTransitions()
 
 	err := NewError()
-	err.Addf("transitions don't use place with id 'a'")
+	err.Add(NewIssuef(RuleAllPlacesInTransitions, "transitions don't use place with id 'a'").WithPlaces("a"))
 	assert.Equal(t, err, v.Validate(minCfg))
 }
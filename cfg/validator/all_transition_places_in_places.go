@@ -14,6 +14,17 @@ func NewAllTransitionPlacesInPlaces() *AllTransitionPlacesInPlaces {
 func (a *AllTransitionPlacesInPlaces) Validate(c cfg.Interface) error {
 	m := buildPlaceRegistryFromTransitions(c.GetTransitions())
 
+	for _, transition := range c.GetTransitions().GetAsMap() {
+		cancelling, ok := transition.(cfg.CancellingTransitionInterface)
+		if !ok {
+			continue
+		}
+
+		for _, place := range cancelling.GetCancel() {
+			m[place.GetID()] = struct{}{}
+		}
+	}
+
 	for _, place := range c.GetPlaces() {
 		delete(m, place.GetID())
 	}
@@ -25,7 +36,11 @@ func (a *AllTransitionPlacesInPlaces) Validate(c cfg.Interface) error {
 	err := NewError()
 
 	for k := range m {
-		err.Addf("place with id '%s' from transitions is not found in places", k)
+		err.Add(NewIssuef(
+			RuleAllTransitionPlacesInPlaces,
+			"place with id '%s' from transitions is not found in places",
+			k,
+		).WithPlaces(k))
 	}
 
 	return err
@@ -45,7 +45,60 @@ func TestAllTransitionPlacesInPlaces_Validate_NotValidCfg_ExpectedErr(t *testing
 
 	assert.Equal(
 		t,
-		BuildErrorf("place with id 'a' from transitions is not found in places"),
+		BuildError(NewIssuef(
+			RuleAllTransitionPlacesInPlaces,
+			"place with id 'a' from transitions is not found in places",
+		).WithPlaces("a")),
+		v.Validate(minCfg),
+	)
+}
+
+func TestAllTransitionPlacesInPlaces_Validate_CancelPlaceNotInPlaces_ExpectedErr(t *testing.T) {
+	minCfg := cfg.Minimal{
+		Start:  "",
+		Finish: "",
+		Places: []cfg.StringID{"a"},
+		Transitions: map[string]cfg.MinimalTransition{
+			"b": {
+				From:   []cfg.StringID{"a"},
+				Cancel: []cfg.StringID{"c"},
+			},
+		},
+	}
+
+	v := NewAllTransitionPlacesInPlaces()
+
+	assert.Equal(
+		t,
+		BuildError(NewIssuef(
+			RuleAllTransitionPlacesInPlaces,
+			"place with id 'c' from transitions is not found in places",
+		).WithPlaces("c")),
+		v.Validate(minCfg),
+	)
+}
+
+func TestAllTransitionPlacesInPlaces_Validate_ErrorPlaceNotInPlaces_ExpectedErr(t *testing.T) {
+	minCfg := cfg.Minimal{
+		Start:  "",
+		Finish: "",
+		Places: []cfg.StringID{"a"},
+		Transitions: map[string]cfg.MinimalTransition{
+			"b": {
+				From:       []cfg.StringID{"a"},
+				ErrorPlace: "e",
+			},
+		},
+	}
+
+	v := NewAllTransitionPlacesInPlaces()
+
+	assert.Equal(
+		t,
+		BuildError(NewIssuef(
+			RuleAllTransitionPlacesInPlaces,
+			"place with id 'e' from transitions is not found in places",
+		).WithPlaces("e")),
 		v.Validate(minCfg),
 	)
 }
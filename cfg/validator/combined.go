@@ -1,15 +1,24 @@
 package validator
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/clock"
 )
 
 type Validator interface {
 	Validate(c cfg.Interface) error
 }
 
+// Combined runs validators one by one.
+// By default it returns the err of the first failed validator, use WithCollectAll or Report for getting all issues.
 type Combined struct {
 	validators []Validator
+	collectAll bool
+	warnings   map[Rule]struct{}
+	clock      clock.Clock
 }
 
 func New(validators ...Validator) *Combined {
@@ -22,16 +31,118 @@ func New(validators ...Validator) *Combined {
 	}
 }
 
+// WithCollectAll switches Validate to running of all validators,
+// issues of all validators are merged into one *Error.
+func (c *Combined) WithCollectAll() *Combined {
+	c.collectAll = true
+
+	return c
+}
+
+// WithWarnings downgrades issues of the rules to warnings.
+// Warnings don't fail validation, Validate returns nil if there are only warnings, use Report for getting them.
+// If validation fails, the err contains warnings too: of the failed validator by default
+// and of all validators in collect-all mode.
+func (c *Combined) WithWarnings(rules ...Rule) *Combined {
+	if c.warnings == nil {
+		c.warnings = make(map[Rule]struct{})
+	}
+
+	for _, rule := range rules {
+		c.warnings[rule] = struct{}{}
+	}
+
+	return c
+}
+
+// WithClock set clock for timings of report.
+func (c *Combined) WithClock(clk clock.Clock) *Combined {
+	c.clock = clk
+
+	return c
+}
+
 func (c *Combined) Validate(cfg cfg.Interface) error {
+	if c.collectAll {
+		return c.Report(cfg).Err()
+	}
+
 	for _, v := range c.validators {
-		if err := v.Validate(cfg); err != nil {
+		vErr, err := c.runValidator(v, cfg)
+		if err != nil {
 			return err
 		}
+
+		if vErr != nil && vErr.HasErrors() {
+			return vErr
+		}
 	}
 
 	return nil
 }
 
+// Report runs all validators and merges their issues.
+// Validators are skipped after the first issue if the config is nil or typed nil, they can't check it.
+func (c *Combined) Report(cfg cfg.Interface) *Report {
+	res := NewReport()
+
+	for _, v := range c.validators {
+		start := c.getClock().Now()
+		vErr, err := c.runValidator(v, cfg)
+		res.Timings = append(res.Timings, Timing{
+			Validator: fmt.Sprintf("%T", v),
+			Duration:  c.getClock().Now().Sub(start),
+		})
+
+		if err != nil {
+			vErr = BuildErrorf("%s", err.Error())
+		}
+
+		if vErr != nil {
+			res.Issues = append(res.Issues, vErr.GetIssues()...)
+		}
+
+		if isNil(cfg) && len(res.Issues) > 0 {
+			break
+		}
+	}
+
+	return res
+}
+
+// runValidator returns issues of validator with downgraded rules or err of another type.
+func (c *Combined) runValidator(v Validator, cfg cfg.Interface) (*Error, error) {
+	err := v.Validate(cfg)
+	if err == nil {
+		return nil, nil
+	}
+
+	var vErr *Error
+	if !errors.As(err, &vErr) {
+		return nil, err
+	}
+
+	res := NewError()
+
+	for _, issue := range vErr.GetIssues() {
+		if _, ok := c.warnings[issue.Rule]; ok {
+			issue.Severity = SeverityWarning
+		}
+
+		res.Add(issue)
+	}
+
+	return res, nil
+}
+
+func (c *Combined) getClock() clock.Clock {
+	if c.clock == nil {
+		c.clock = clock.NewSystem()
+	}
+
+	return c.clock
+}
+
 // NewCombinedWithAllValidators return component with all validators.
 func NewCombinedWithAllValidators() *Combined {
 	return New(
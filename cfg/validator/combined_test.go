@@ -1,12 +1,16 @@
 package validator
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/clock"
 )
 
 type validatorMock struct {
@@ -50,3 +54,199 @@ func TestCombined_Validate_ErrHappened_Err(t *testing.T) {
 	assert.Equal(t, errors.New("a"), com.Validate(&cfg.Minimal{}))
 	assert.Equal(t, 1, v.callsNum, "unexpected numbers of mock calls")
 }
+
+func TestNewCombinedWithAllValidators_CfgWithErrorPlace_NoErr(t *testing.T) {
+	minCfg := cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "failed", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"send":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}, ErrorPlace: "failed"},
+			"retry": {From: []cfg.StringID{"failed"}, To: []cfg.StringID{"start"}},
+		},
+	}
+
+	assert.NoError(t, NewCombinedWithAllValidators().Validate(minCfg))
+}
+
+func TestNewCombinedWithAllValidators_ErrorPlaceWithoutRecovery_ExpectedErr(t *testing.T) {
+	minCfg := cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "failed", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"send":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}, ErrorPlace: "failed"},
+			"close": {From: []cfg.StringID{"failed"}},
+		},
+	}
+
+	assert.Equal(
+		t,
+		BuildError(NewIssuef(RuleNonFinishPlaces, "place with id 'failed' is non-finish place").WithPlaces("failed")),
+		NewCombinedWithAllValidators().Validate(minCfg),
+	)
+}
+
+type timedValidatorMock struct {
+	clock *clock.Fake
+	err   error
+}
+
+func (v *timedValidatorMock) Validate(c cfg.Interface) error {
+	v.clock.Add(time.Second)
+
+	return v.err
+}
+
+func TestCombined_Validate_WithWarnings_WarningsDontFail(t *testing.T) {
+	v1 := &validatorMock{err: BuildError(NewIssuef(RuleDeadPlaces, "a"))}
+	v2 := &validatorMock{}
+
+	com := New(v1, v2).WithWarnings(RuleDeadPlaces)
+
+	assert.NoError(t, com.Validate(&cfg.Minimal{}))
+	assert.Equal(t, 1, v2.callsNum, "unexpected numbers of mock calls")
+}
+
+func TestCombined_Validate_WithWarnings_ErrContainsWarnings(t *testing.T) {
+	v := &validatorMock{err: BuildError(NewIssuef(RuleDeadPlaces, "a"), NewIssuef(RuleEmpty, "b"))}
+
+	warning := NewIssuef(RuleDeadPlaces, "a")
+	warning.Severity = SeverityWarning
+
+	assert.Equal(
+		t,
+		BuildError(warning, NewIssuef(RuleEmpty, "b")),
+		New(v).WithWarnings(RuleDeadPlaces).Validate(&cfg.Minimal{}),
+	)
+	assert.Equal(t, BuildError(NewIssuef(RuleDeadPlaces, "a"), NewIssuef(RuleEmpty, "b")), v.err, "source err is changed")
+}
+
+func TestCombined_Validate_WithCollectAll_AllIssues(t *testing.T) {
+	v1 := &validatorMock{err: BuildError(NewIssuef(RuleDeadPlaces, "a"))}
+	v2 := &validatorMock{}
+	v3 := &validatorMock{err: errors.New("b")}
+
+	assert.Equal(
+		t,
+		BuildError(NewIssuef(RuleDeadPlaces, "a"), NewIssuef("", "b")),
+		New(v1, v2, v3).WithCollectAll().Validate(&cfg.Minimal{}),
+	)
+	assert.Equal(t, 1, v3.callsNum, "unexpected numbers of mock calls")
+}
+
+func TestCombined_Validate_WithCollectAll_OnlyWarnings_NoErr(t *testing.T) {
+	v := &validatorMock{err: BuildError(NewIssuef(RuleDeadPlaces, "a"))}
+
+	assert.NoError(t, New(v).WithCollectAll().WithWarnings(RuleDeadPlaces).Validate(&cfg.Minimal{}))
+}
+
+func TestCombined_OnlyWarnings_WarningsAreInReport(t *testing.T) {
+	minCfg := cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "c", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"t": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}},
+			"u": {From: []cfg.StringID{"c"}, To: []cfg.StringID{"finish"}},
+		},
+	}
+
+	warning := NewIssuef(RuleDeadPlaces, "place with id 'c' is dead place").WithPlaces("c")
+	warning.Severity = SeverityWarning
+
+	for name, com := range map[string]*Combined{
+		"default":    New(NewDeadPlaces(NewCfgTreeBuilder())).WithWarnings(RuleDeadPlaces),
+		"collectAll": New(NewDeadPlaces(NewCfgTreeBuilder())).WithWarnings(RuleDeadPlaces).WithCollectAll(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.NoError(t, com.Validate(minCfg))
+
+			report := com.Report(minCfg)
+			assert.Equal(t, []Issue{warning}, report.Issues)
+			assert.False(t, report.HasErrors())
+			assert.NoError(t, report.Err())
+		})
+	}
+}
+
+func TestCombined_Report_ExpectedReport(t *testing.T) {
+	clk := clock.NewFake(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	v1 := &timedValidatorMock{clock: clk, err: BuildError(NewIssuef(RuleDeadPlaces, "a").WithPlaces("c"))}
+	v2 := &timedValidatorMock{clock: clk}
+
+	warning := NewIssuef(RuleDeadPlaces, "a").WithPlaces("c")
+	warning.Severity = SeverityWarning
+
+	report := New(v1, v2).WithWarnings(RuleDeadPlaces).WithClock(clk).Report(&cfg.Minimal{})
+
+	assert.Equal(
+		t,
+		&Report{
+			Issues: []Issue{warning},
+			Timings: []Timing{
+				{Validator: "*validator.timedValidatorMock", Duration: time.Second},
+				{Validator: "*validator.timedValidatorMock", Duration: time.Second},
+			},
+		},
+		report,
+	)
+	assert.False(t, report.HasErrors())
+	assert.NoError(t, report.Err())
+
+	bytes, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`{"issues":[{"rule":"deadPlaces","severity":"warning","places":["c"],"message":"a"}],`+
+			`"timings":[{"validator":"*validator.timedValidatorMock","duration":1000000000},`+
+			`{"validator":"*validator.timedValidatorMock","duration":1000000000}]}`,
+		string(bytes),
+	)
+}
+
+func TestNewCombinedWithAllValidators_Report_AllIssues(t *testing.T) {
+	minCfg := cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "start", "finish", "lost"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"send": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}},
+		},
+	}
+
+	report := NewCombinedWithAllValidators().Report(minCfg)
+
+	assert.Equal(
+		t,
+		[]string{
+			"transitions don't use place with id 'lost'",
+			"place with id 'start' is duplicated",
+			"place with id 'lost' is dead place",
+			"place with id 'lost' is non-finish place",
+		},
+		BuildError(report.Issues...).Get(),
+	)
+	assert.Len(t, report.Timings, 10)
+	assert.True(t, report.HasErrors())
+}
+
+func TestNewCombinedWithAllValidators_Report_NilCfg_OneIssue(t *testing.T) {
+	report := NewCombinedWithAllValidators().Report(nil)
+
+	assert.Equal(t, []Issue{NewIssuef(RuleNotNil, "config of net can't be nil")}, report.Issues)
+	assert.Len(t, report.Timings, 1)
+}
+
+func TestNewCombinedWithAllValidators_Report_TypedNilCfg_OneIssue(t *testing.T) {
+	report := NewCombinedWithAllValidators().Report((*cfg.Minimal)(nil))
+
+	assert.Equal(t, []Issue{NewIssuef(RuleNotNil, "config of net can't be nil")}, report.Issues)
+	assert.Len(t, report.Timings, 1)
+}
+
+func TestNewCombinedWithAllValidators_Validate_TypedNilCfg_ExpectedErr(t *testing.T) {
+	err := NewCombinedWithAllValidators().WithCollectAll().Validate((*cfg.Minimal)(nil))
+
+	assert.Equal(t, BuildError(NewIssuef(RuleNotNil, "config of net can't be nil")), err)
+}
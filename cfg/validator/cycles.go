@@ -0,0 +1,279 @@
+package validator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/andrskom/gowfnet/cfg"
+)
+
+const RuleCycles Rule = "cycles"
+
+// Cycle is a strongly connected set of places, e.g. a rework loop.
+// Transitions are transitions which move tokens between places of the cycle.
+// Exits are places out of the cycle which are reachable by one transition from places of the cycle.
+// Livelock is true if the finish place or a place of an outcome isn't reachable from the cycle.
+type Cycle struct {
+	Places      []string `json:"places"`
+	Transitions []string `json:"transitions"`
+	Exits       []string `json:"exits"`
+	Livelock    bool     `json:"livelock"`
+}
+
+// Cycles finds cycles of config by strongly connected components of Tarjan.
+// Cycles with an exit to the finish are valid, Validate returns issues for livelocks only.
+type Cycles struct {
+	treeBuilder TreeBuilder
+}
+
+func NewCycles(treeBuilder TreeBuilder) *Cycles {
+	return &Cycles{treeBuilder: treeBuilder}
+}
+
+func (c *Cycles) Validate(config cfg.Interface) error {
+	cycles, err := c.Analyze(config)
+	if err != nil {
+		return err
+	}
+
+	vErr := NewError()
+
+	for _, cycle := range cycles {
+		if !cycle.Livelock {
+			continue
+		}
+
+		vErr.Add(NewIssuef(
+			RuleCycles,
+			"places '%s' form cycle by transitions '%s' without exit to finish",
+			strings.Join(cycle.Places, "', '"),
+			strings.Join(cycle.Transitions, "', '"),
+		).WithPlaces(cycle.Places...).WithTransitions(cycle.Transitions...))
+	}
+
+	return PrepareResultErr(vErr)
+}
+
+// Analyze returns all cycles of config sorted by the first place, places of cycle are sorted too.
+func (c *Cycles) Analyze(config cfg.Interface) ([]Cycle, error) {
+	tree, err := c.treeBuilder.Build(config)
+	if err != nil {
+		return nil, err
+	}
+
+	terminals, err := tree.GetTerminalNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	finishing := buildFinishingNodes(terminals)
+	res := make([]Cycle, 0)
+
+	for _, component := range newTarjan().run(tree) {
+		if !isCycle(component) {
+			continue
+		}
+
+		res = append(res, buildCycle(component, finishing, config.GetTransitions().GetAsMap()))
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Places[0] < res[j].Places[0]
+	})
+
+	return res, nil
+}
+
+// tarjan keeps state of search of strongly connected components.
+type tarjan struct {
+	index      int
+	indexes    map[string]int
+	lowLinks   map[string]int
+	onStack    map[string]struct{}
+	stack      *NodeStack
+	components [][]*TreeNode
+}
+
+func newTarjan() *tarjan {
+	return &tarjan{
+		indexes:    make(map[string]int),
+		lowLinks:   make(map[string]int),
+		onStack:    make(map[string]struct{}),
+		stack:      NewNodeStack(),
+		components: make([][]*TreeNode, 0),
+	}
+}
+
+func (t *tarjan) run(tree *Tree) [][]*TreeNode {
+	for _, node := range sortNodes(tree.GetNodeRegistry()) {
+		if _, ok := t.indexes[node.GetID()]; !ok {
+			t.strongConnect(node)
+		}
+	}
+
+	return t.components
+}
+
+func (t *tarjan) strongConnect(node *TreeNode) {
+	id := node.GetID()
+	t.indexes[id] = t.index
+	t.lowLinks[id] = t.index
+	t.index++
+
+	t.stack.Push(node)
+	t.onStack[id] = struct{}{}
+
+	for _, toNode := range sortNodes(node.GetTo()) {
+		toID := toNode.GetID()
+
+		if _, ok := t.indexes[toID]; !ok {
+			t.strongConnect(toNode)
+			t.lowLinks[id] = minInt(t.lowLinks[id], t.lowLinks[toID])
+		} else if _, ok := t.onStack[toID]; ok {
+			t.lowLinks[id] = minInt(t.lowLinks[id], t.indexes[toID])
+		}
+	}
+
+	if t.lowLinks[id] != t.indexes[id] {
+		return
+	}
+
+	component := make([]*TreeNode, 0)
+
+	for {
+		// The stack isn't empty, the node is in it.
+		member, _ := t.stack.Pop()
+		delete(t.onStack, member.GetID())
+		component = append(component, member)
+
+		if member == node {
+			break
+		}
+	}
+
+	t.components = append(t.components, component)
+}
+
+// isCycle returns true if the component has more than one node or the node has a loop.
+func isCycle(component []*TreeNode) bool {
+	if len(component) > 1 {
+		return true
+	}
+
+	_, ok := component[0].GetTo()[component[0].GetID()]
+
+	return ok
+}
+
+func buildCycle(
+	component []*TreeNode,
+	finishing map[string]struct{},
+	transitions map[string]cfg.TransitionInterface,
+) Cycle {
+	members := make(map[string]struct{}, len(component))
+	for _, node := range component {
+		members[node.GetID()] = struct{}{}
+	}
+
+	res := Cycle{
+		Places:      make([]string, 0, len(component)),
+		Transitions: buildCycleTransitions(members, transitions),
+		Exits:       make([]string, 0),
+		Livelock:    true,
+	}
+	exits := make(map[string]struct{})
+
+	for _, node := range component {
+		res.Places = append(res.Places, node.GetID())
+
+		if _, ok := finishing[node.GetID()]; ok {
+			res.Livelock = false
+		}
+
+		for id := range node.GetTo() {
+			if _, ok := members[id]; !ok {
+				exits[id] = struct{}{}
+			}
+		}
+	}
+
+	for id := range exits {
+		res.Exits = append(res.Exits, id)
+	}
+
+	sort.Strings(res.Places)
+	sort.Strings(res.Exits)
+
+	return res
+}
+
+// buildCycleTransitions returns sorted names of transitions which move tokens from a member to a member.
+func buildCycleTransitions(members map[string]struct{}, transitions map[string]cfg.TransitionInterface) []string {
+	res := make([]string, 0)
+
+	for name, transition := range transitions {
+		if hasMember(members, transition.GetFrom()) && hasMember(members, transition.GetTo()) {
+			res = append(res, name)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+func hasMember(members map[string]struct{}, places []cfg.IDGetter) bool {
+	for _, place := range places {
+		if _, ok := members[place.GetID()]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// buildFinishingNodes returns ids of nodes from which one of terminal nodes is reachable.
+func buildFinishingNodes(terminals []*TreeNode) map[string]struct{} {
+	res := make(map[string]struct{})
+	stack := NewNodeStack()
+
+	for _, node := range terminals {
+		stack.Push(node)
+	}
+
+	for stack.Len() > 0 {
+		node, _ := stack.Pop()
+		if _, ok := res[node.GetID()]; ok {
+			continue
+		}
+
+		res[node.GetID()] = struct{}{}
+
+		for _, fromNode := range node.GetFrom() {
+			stack.Push(fromNode)
+		}
+	}
+
+	return res
+}
+
+func sortNodes(nodes map[string]*TreeNode) []*TreeNode {
+	res := make([]*TreeNode, 0, len(nodes))
+	for _, node := range nodes {
+		res = append(res, node)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].GetID() < res[j].GetID()
+	})
+
+	return res
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
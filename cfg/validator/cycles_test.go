@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet/cfg"
+)
+
+var reworkCfg = cfg.Minimal{ // nolint:gochecknoglobals
+	Start:  "start",
+	Finish: "finish",
+	Places: []cfg.StringID{"start", "review", "changes", "finish"},
+	Transitions: cfg.MinimalTransitionRegistry{
+		"submit":         {From: []cfg.StringID{"start"}, To: []cfg.StringID{"review"}},
+		"requestChanges": {From: []cfg.StringID{"review"}, To: []cfg.StringID{"changes"}},
+		"resubmit":       {From: []cfg.StringID{"changes"}, To: []cfg.StringID{"review"}},
+		"approve":        {From: []cfg.StringID{"review"}, To: []cfg.StringID{"finish"}},
+	},
+}
+
+func TestNewCycles(t *testing.T) {
+	assert.Equal(t, &Cycles{treeBuilder: NewCfgTreeBuilder()}, NewCycles(NewCfgTreeBuilder()))
+}
+
+func TestCycles_Validate_BuilderErr_TheSameErr(t *testing.T) {
+	builderMock := NewBuilderMock()
+
+	var (
+		minCfg  *cfg.Minimal
+		mockRes *Tree
+	)
+
+	eErr := errors.New("expectedErr")
+	builderMock.On("Build", minCfg).Return(mockRes, eErr)
+
+	assert.Same(t, eErr, NewCycles(builderMock).Validate(minCfg))
+}
+
+func TestCycles_Analyze_ReworkLoop_CycleWithExit(t *testing.T) {
+	cycles, err := NewCycles(NewCfgTreeBuilder()).Analyze(reworkCfg)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]Cycle{{
+			Places:      []string{"changes", "review"},
+			Transitions: []string{"requestChanges", "resubmit"},
+			Exits:       []string{"finish"},
+			Livelock:    false,
+		}},
+		cycles,
+	)
+	assert.NoError(t, NewCycles(NewCfgTreeBuilder()).Validate(reworkCfg))
+}
+
+func TestCycles_Analyze_WithoutCycles_Empty(t *testing.T) {
+	minCfg := cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"a": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}},
+		},
+	}
+
+	cycles, err := NewCycles(NewCfgTreeBuilder()).Analyze(minCfg)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Cycle{}, cycles)
+}
+
+func TestCycles_Analyze_SelfLoopAndLivelock_ExpectedCycles(t *testing.T) {
+	minCfg := cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "wait", "a", "b", "c", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"begin":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"wait", "a"}},
+			"poll":   {From: []cfg.StringID{"wait"}, To: []cfg.StringID{"wait"}},
+			"done":   {From: []cfg.StringID{"wait"}, To: []cfg.StringID{"finish"}},
+			"toB":    {From: []cfg.StringID{"a"}, To: []cfg.StringID{"b"}},
+			"toA":    {From: []cfg.StringID{"b"}, To: []cfg.StringID{"a"}},
+			"escape": {From: []cfg.StringID{"b"}, To: []cfg.StringID{"c"}},
+		},
+	}
+
+	cycles, err := NewCycles(NewCfgTreeBuilder()).Analyze(minCfg)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]Cycle{
+			{Places: []string{"a", "b"}, Transitions: []string{"toA", "toB"}, Exits: []string{"c"}, Livelock: true},
+			{Places: []string{"wait"}, Transitions: []string{"poll"}, Exits: []string{"finish"}, Livelock: false},
+		},
+		cycles,
+	)
+	assert.Equal(
+		t,
+		BuildError(
+			NewIssuef(RuleCycles, "places 'a', 'b' form cycle by transitions 'toA', 'toB' without exit to finish").
+				WithPlaces("a", "b").
+				WithTransitions("toA", "toB"),
+		),
+		NewCycles(NewCfgTreeBuilder()).Validate(minCfg),
+	)
+}
+
+func TestCycles_Analyze_ExitToOutcome_NotLivelock(t *testing.T) {
+	minCfg := cfg.Minimal{
+		Start:    "start",
+		Places:   []cfg.StringID{"start", "review", "changes", "rejected"},
+		Outcomes: map[string]cfg.StringID{"rejected": "rejected"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"submit":         {From: []cfg.StringID{"start"}, To: []cfg.StringID{"review"}},
+			"requestChanges": {From: []cfg.StringID{"review"}, To: []cfg.StringID{"changes"}},
+			"resubmit":       {From: []cfg.StringID{"changes"}, To: []cfg.StringID{"review"}},
+			"reject":         {From: []cfg.StringID{"changes"}, To: []cfg.StringID{"rejected"}},
+		},
+	}
+
+	cycles, err := NewCycles(NewCfgTreeBuilder()).Analyze(minCfg)
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		[]Cycle{{
+			Places:      []string{"changes", "review"},
+			Transitions: []string{"requestChanges", "resubmit"},
+			Exits:       []string{"rejected"},
+			Livelock:    false,
+		}},
+		cycles,
+	)
+}
@@ -36,9 +36,13 @@ func (d *DeadPlaces) Validate(c cfg.Interface) error {
 		switch node.GetColor() {
 		case ColorBlack:
 		case ColorGray:
-			vErr.Addf("unexpected situation for dead places validator in place with id '%s'", node.GetID())
+			vErr.Add(NewIssuef(
+				RuleDeadPlaces,
+				"unexpected situation for dead places validator in place with id '%s'",
+				node.GetID(),
+			).WithPlaces(node.GetID()))
 		case ColorWhite:
-			vErr.Addf("place with id '%s' is dead place", node.GetID())
+			vErr.Add(NewIssuef(RuleDeadPlaces, "place with id '%s' is dead place", node.GetID()).WithPlaces(node.GetID()))
 		default:
 			return errors.New("unexpected color of node")
 		}
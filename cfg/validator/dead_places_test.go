@@ -66,7 +66,7 @@ func TestDeadPlaces_Validate_NotValidCfg_ExpectedErr(t *testing.T) {
 					},
 				},
 			},
-			expected: BuildErrorf("place with id 'c' is dead place"),
+			expected: BuildError(NewIssuef(RuleDeadPlaces, "place with id 'c' is dead place").WithPlaces("c")),
 		},
 		"net with branch error": {
 			cfg: cfg.Minimal{
@@ -84,7 +84,7 @@ func TestDeadPlaces_Validate_NotValidCfg_ExpectedErr(t *testing.T) {
 					},
 				},
 			},
-			expected: BuildErrorf("place with id 'c' is dead place"),
+			expected: BuildError(NewIssuef(RuleDeadPlaces, "place with id 'c' is dead place").WithPlaces("c")),
 		},
 	}
 
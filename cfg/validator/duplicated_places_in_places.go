@@ -17,7 +17,11 @@ func (s *DuplicatedPlacesInPlaces) Validate(c cfg.Interface) error {
 
 	for _, place := range c.GetPlaces() {
 		if _, ok := checkMap[place.GetID()]; ok {
-			err.Addf("place with id '%s' is duplicated", place.GetID())
+			err.Add(NewIssuef(
+				RuleDuplicatedPlacesInPlaces,
+				"place with id '%s' is duplicated",
+				place.GetID(),
+			).WithPlaces(place.GetID()))
 		}
 
 		checkMap[place.GetID()] = struct{}{}
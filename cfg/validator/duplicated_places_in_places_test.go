@@ -23,7 +23,14 @@ func TestDuplicatedPlacesInPlaces_Validate_RepeatVal_ExpectedErr(t *testing.T) {
 
 	v := NewDuplicatedPlacesInPlaces()
 
-	assert.Equal(t, BuildErrorf("place with id 'a' is duplicated"), v.Validate(minCfg))
+	assert.Equal(
+		t,
+		BuildError(NewIssuef(
+			RuleDuplicatedPlacesInPlaces,
+			"place with id 'a' is duplicated",
+		).WithPlaces("a")),
+		v.Validate(minCfg),
+	)
 }
 
 func TestDuplicatedPlacesInPlaces_Validate_CorrectCfg_NoErr(t *testing.T) {
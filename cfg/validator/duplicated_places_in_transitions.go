@@ -19,10 +19,11 @@ func (d *DuplicatedPlacesInTransitions) Validate(c cfg.Interface) error {
 
 		for _, place := range transition.GetFrom() {
 			if _, ok := checkMap[place.GetID()]; ok {
-				err.Addf(
+				err.Add(NewIssuef(
+					RuleDuplicatedPlacesInTransitions,
 					"place with id '%s' is duplicated in transition with id '%s' in section from",
 					place.GetID(), transitionName,
-				)
+				).WithPlaces(place.GetID()).WithTransitions(transitionName))
 			}
 
 			checkMap[place.GetID()] = struct{}{}
@@ -32,10 +33,11 @@ func (d *DuplicatedPlacesInTransitions) Validate(c cfg.Interface) error {
 
 		for _, place := range transition.GetTo() {
 			if _, ok := checkMap[place.GetID()]; ok {
-				err.Addf(
+				err.Add(NewIssuef(
+					RuleDuplicatedPlacesInTransitions,
 					"place with id '%s' is duplicated in transition with id '%s' in section to",
 					place.GetID(), transitionName,
-				)
+				).WithPlaces(place.GetID()).WithTransitions(transitionName))
 			}
 
 			checkMap[place.GetID()] = struct{}{}
@@ -25,7 +25,10 @@ func TestDuplicatedPlacesInTransitions_Validate_DuplicatedInFrom_ExpectedErr(t *
 
 	assert.Equal(
 		t,
-		BuildErrorf("place with id 'b' is duplicated in transition with id 'a' in section from"),
+		BuildError(NewIssuef(
+			RuleDuplicatedPlacesInTransitions,
+			"place with id 'b' is duplicated in transition with id 'a' in section from",
+		).WithPlaces("b").WithTransitions("a")),
 		v.Validate(minCfg),
 	)
 }
@@ -43,7 +46,10 @@ func TestDuplicatedPlacesInTransitions_Validate_DuplicatedInTo_ExpectedErr(t *te
 
 	assert.Equal(
 		t,
-		BuildErrorf("place with id 'b' is duplicated in transition with id 'a' in section to"),
+		BuildError(NewIssuef(
+			RuleDuplicatedPlacesInTransitions,
+			"place with id 'b' is duplicated in transition with id 'a' in section to",
+		).WithPlaces("b").WithTransitions("a")),
 		v.Validate(minCfg),
 	)
 }
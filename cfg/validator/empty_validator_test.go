@@ -25,6 +25,19 @@ func TestEmpty_Validate_ValidCfg_NoErr(t *testing.T) {
 	assert.NoError(t, v.Validate(minCfg))
 }
 
+func TestEmpty_Validate_EmptyFinishWithOutcomes_NoErr(t *testing.T) {
+	v := NewEmpty()
+
+	minCfg := cfg.Minimal{
+		Start:       "a",
+		Outcomes:    map[string]cfg.StringID{"approved": "b"},
+		Places:      []cfg.StringID{"c"},
+		Transitions: cfg.MinimalTransitionRegistry{"d": {}},
+	}
+
+	assert.NoError(t, v.Validate(minCfg))
+}
+
 func TestEmpty_Validate_NotValidCfg_ExpectedErr(t *testing.T) {
 	type Data struct {
 		cfg         cfg.Minimal
@@ -39,7 +52,7 @@ func TestEmpty_Validate_NotValidCfg_ExpectedErr(t *testing.T) {
 				Places:      []cfg.StringID{"c"},
 				Transitions: cfg.MinimalTransitionRegistry{"d": {}},
 			},
-			expectedErr: BuildErrorf("start place id is empty"),
+			expectedErr: BuildError(NewIssuef(RuleEmpty, "start place id is empty")),
 		},
 		"empty finish": {
 			cfg: cfg.Minimal{
@@ -48,7 +61,7 @@ func TestEmpty_Validate_NotValidCfg_ExpectedErr(t *testing.T) {
 				Places:      []cfg.StringID{"c"},
 				Transitions: cfg.MinimalTransitionRegistry{"d": {}},
 			},
-			expectedErr: BuildErrorf("finish place id is empty"),
+			expectedErr: BuildError(NewIssuef(RuleEmpty, "finish place id is empty")),
 		},
 		"empty places": {
 			cfg: cfg.Minimal{
@@ -57,7 +70,7 @@ func TestEmpty_Validate_NotValidCfg_ExpectedErr(t *testing.T) {
 				Places:      nil,
 				Transitions: cfg.MinimalTransitionRegistry{"d": {}},
 			},
-			expectedErr: BuildErrorf("places is empty"),
+			expectedErr: BuildError(NewIssuef(RuleEmpty, "places is empty")),
 		},
 		"empty transitions": {
 			cfg: cfg.Minimal{
@@ -66,7 +79,7 @@ func TestEmpty_Validate_NotValidCfg_ExpectedErr(t *testing.T) {
 				Places:      []cfg.StringID{"c"},
 				Transitions: nil,
 			},
-			expectedErr: BuildErrorf("transitions registry is empty"),
+			expectedErr: BuildError(NewIssuef(RuleEmpty, "transitions registry is empty")),
 		},
 	}
 
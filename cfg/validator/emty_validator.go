@@ -4,6 +4,8 @@ import (
 	"github.com/andrskom/gowfnet/cfg"
 )
 
+// Empty checks that required fields are set.
+// The finish place can be empty if the config has outcomes.
 type Empty struct {
 }
 
@@ -15,19 +17,19 @@ func (e Empty) Validate(c cfg.Interface) error {
 	err := NewError()
 
 	if len(c.GetStart().GetID()) == 0 {
-		err.Addf("start place id is empty")
+		err.Add(NewIssuef(RuleEmpty, "start place id is empty"))
 	}
 
-	if len(c.GetFinish().GetID()) == 0 {
-		err.Addf("finish place id is empty")
+	if _, ok := cfg.GetTerminalPlaces(c)[""]; ok {
+		err.Add(NewIssuef(RuleEmpty, "finish place id is empty"))
 	}
 
 	if len(c.GetPlaces()) == 0 {
-		err.Addf("places is empty")
+		err.Add(NewIssuef(RuleEmpty, "places is empty"))
 	}
 
 	if len(c.GetTransitions().GetAsMap()) == 0 {
-		err.Addf("transitions registry is empty")
+		err.Add(NewIssuef(RuleEmpty, "transitions registry is empty"))
 	}
 
 	return PrepareResultErr(err)
@@ -1,19 +1,77 @@
 package validator
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
 
+// Severity of validation issue.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Rule is id of the check which found validation issue.
+type Rule string
+
+const (
+	RuleNotNil                        Rule = "notNil"
+	RuleEmpty                         Rule = "empty"
+	RuleStartPlaceInPlaces            Rule = "startPlaceInPlaces"
+	RuleFinishPlaceInPlaces           Rule = "finishPlaceInPlaces"
+	RuleAllPlacesInTransitions        Rule = "allPlacesInTransitions"
+	RuleAllTransitionPlacesInPlaces   Rule = "allTransitionPlacesInPlaces"
+	RuleDuplicatedPlacesInPlaces      Rule = "duplicatedPlacesInPlaces"
+	RuleDuplicatedPlacesInTransitions Rule = "duplicatedPlacesInTransitions"
+	RuleDeadPlaces                    Rule = "deadPlaces"
+	RuleNonFinishPlaces               Rule = "nonFinishPlaces"
+)
+
+// Issue is one problem of config found by validation.
+// Places and Transitions contain ids of elements of config which the issue is about.
+type Issue struct {
+	Rule        Rule     `json:"rule,omitempty"`
+	Severity    Severity `json:"severity"`
+	Places      []string `json:"places,omitempty"`
+	Transitions []string `json:"transitions,omitempty"`
+	Message     string   `json:"message"`
+}
+
+// NewIssuef init issue of the rule with error severity by format.
+func NewIssuef(rule Rule, format string, args ...interface{}) Issue {
+	return Issue{
+		Rule:     rule,
+		Severity: SeverityError,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// WithPlaces returns copy of the issue with ids of places.
+func (i Issue) WithPlaces(ids ...string) Issue {
+	i.Places = ids
+
+	return i
+}
+
+// WithTransitions returns copy of the issue with ids of transitions.
+func (i Issue) WithTransitions(ids ...string) Issue {
+	i.Transitions = ids
+
+	return i
+}
+
 // Error is struct for validation errors.
 type Error struct {
-	list []string
+	issues []Issue
 }
 
 // NewError init error.
 func NewError() *Error {
 	return &Error{
-		list: make([]string, 0),
+		issues: make([]Issue, 0),
 	}
 }
 
@@ -25,23 +83,91 @@ func BuildErrorf(format string, args ...interface{}) *Error {
 	return err
 }
 
+// BuildError return new created err with added issues.
+func BuildError(issues ...Issue) *Error {
+	err := NewError()
+
+	for _, issue := range issues {
+		err.Add(issue)
+	}
+
+	return err
+}
+
 // Addf message to err list.
+// The issue is added without rule and with error severity, use Add for structured issue.
 func (e *Error) Addf(format string, args ...interface{}) {
-	e.list = append(e.list, fmt.Sprintf(format, args...))
+	e.Add(NewIssuef("", format, args...))
+}
+
+// Add issue to err list.
+func (e *Error) Add(issue Issue) {
+	e.issues = append(e.issues, issue)
 }
 
 // Has errors.
 func (e *Error) Has() bool {
-	return len(e.list) > 0
+	return len(e.issues) > 0
 }
 
-// Has errors.
+// HasErrors returns true if err has issues with error severity.
+func (e *Error) HasErrors() bool {
+	for _, issue := range e.issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Get messages of issues.
 func (e *Error) Get() []string {
-	return e.list
+	res := make([]string, 0, len(e.issues))
+
+	for _, issue := range e.issues {
+		res = append(res, issue.Message)
+	}
+
+	return res
+}
+
+// GetIssues of err.
+// Result isn't immutable.
+func (e *Error) GetIssues() []Issue {
+	return e.issues
 }
 
 func (e *Error) Error() string {
-	return " - " + strings.Join(e.list, "\n - ") + "\n"
+	return " - " + strings.Join(e.Get(), "\n - ") + "\n"
+}
+
+type jsonError struct {
+	Issues []Issue `json:"issues"`
+}
+
+func (e Error) MarshalJSON() ([]byte, error) {
+	issues := e.issues
+	if issues == nil {
+		issues = make([]Issue, 0)
+	}
+
+	return json.Marshal(jsonError{Issues: issues})
+}
+
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var res jsonError
+
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+
+	e.issues = res.Issues
+	if e.issues == nil {
+		e.issues = make([]Issue, 0)
+	}
+
+	return nil
 }
 
 func PrepareResultErr(err *Error) error {
@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,7 +12,7 @@ func TestNewError(t *testing.T) {
 	require.Equal(
 		t,
 		&Error{
-			list: make([]string, 0),
+			issues: make([]Issue, 0),
 		},
 		NewError(),
 	)
@@ -31,9 +32,9 @@ func TestError_Add(t *testing.T) {
 	require.Equal(
 		t,
 		&Error{
-			list: []string{
-				"a",
-				"b c",
+			issues: []Issue{
+				{Severity: SeverityError, Message: "a"},
+				{Severity: SeverityError, Message: "b c"},
 			},
 		},
 		err,
@@ -72,10 +73,78 @@ func TestPrepareResultErr_HasNotErr_Nil(t *testing.T) {
 func TestPrepareResultErr_HasErr_Err(t *testing.T) {
 	err := NewError()
 	err.Addf("a")
-	assert.Equal(t, &Error{list: []string{"a"}}, PrepareResultErr(err))
+	assert.Equal(t, &Error{issues: []Issue{{Severity: SeverityError, Message: "a"}}}, PrepareResultErr(err))
 }
 
 func TestBuildErrorf(t *testing.T) {
 	err := BuildErrorf("%s", "a")
 	assert.Equal(t, " - a\n", err.Error())
 }
+
+func TestError_AddIssue(t *testing.T) {
+	err := NewError()
+	err.Add(NewIssuef(RuleDeadPlaces, "place with id '%s' is dead place", "a").WithPlaces("a"))
+	err.Add(NewIssuef(RuleDuplicatedPlacesInTransitions, "b").WithPlaces("c").WithTransitions("d"))
+
+	assert.Equal(
+		t,
+		[]Issue{
+			{
+				Rule:     RuleDeadPlaces,
+				Severity: SeverityError,
+				Places:   []string{"a"},
+				Message:  "place with id 'a' is dead place",
+			},
+			{
+				Rule:        RuleDuplicatedPlacesInTransitions,
+				Severity:    SeverityError,
+				Places:      []string{"c"},
+				Transitions: []string{"d"},
+				Message:     "b",
+			},
+		},
+		err.GetIssues(),
+	)
+	assert.Equal(t, []string{"place with id 'a' is dead place", "b"}, err.Get())
+	assert.Equal(t, " - place with id 'a' is dead place\n - b\n", err.Error())
+}
+
+func TestBuildError(t *testing.T) {
+	issue := NewIssuef(RuleEmpty, "a")
+	assert.Equal(t, &Error{issues: []Issue{issue}}, BuildError(issue))
+}
+
+func TestError_Serialization(t *testing.T) {
+	err := BuildError(
+		NewIssuef(RuleDuplicatedPlacesInTransitions, "a").WithPlaces("b").WithTransitions("c"),
+		NewIssuef(RuleEmpty, "d"),
+	)
+
+	bytes, mErr := json.Marshal(err)
+	require.NoError(t, mErr)
+	assert.JSONEq(
+		t,
+		`{"issues":[`+
+			`{"rule":"duplicatedPlacesInTransitions","severity":"error","places":["b"],"transitions":["c"],"message":"a"},`+
+			`{"rule":"empty","severity":"error","message":"d"}`+
+			`]}`,
+		string(bytes),
+	)
+
+	var newErr Error
+
+	require.NoError(t, json.Unmarshal(bytes, &newErr))
+	assert.Equal(t, err, &newErr)
+}
+
+func TestError_Serialization_Empty(t *testing.T) {
+	bytes, err := json.Marshal(&Error{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"issues":[]}`, string(bytes))
+}
+
+func TestError_UnserializeBadData_ExpectedErr(t *testing.T) {
+	var err Error
+
+	require.Error(t, json.Unmarshal([]byte(`{"issues":1}`), &err))
+}
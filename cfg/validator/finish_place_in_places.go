@@ -1,7 +1,13 @@
 package validator
 
-import "github.com/andrskom/gowfnet/cfg"
+import (
+	"sort"
 
+	"github.com/andrskom/gowfnet/cfg"
+)
+
+// FinishPlaceInPlaces checks that the finish place and places of outcomes are in places.
+// The finish place can be empty if the config has outcomes.
 type FinishPlaceInPlaces struct {
 }
 
@@ -10,14 +16,45 @@ func NewFinishPlaceInPlaces() *FinishPlaceInPlaces {
 }
 
 func (s *FinishPlaceInPlaces) Validate(c cfg.Interface) error {
+	places := make(map[string]struct{})
 	for _, place := range c.GetPlaces() {
-		if place.GetID() == c.GetFinish().GetID() {
-			return nil
-		}
+		places[place.GetID()] = struct{}{}
 	}
 
 	err := NewError()
-	err.Addf("finish place is not found in places")
 
-	return err
+	terminals := cfg.GetTerminalPlaces(c)
+	finish := c.GetFinish().GetID()
+
+	if _, ok := terminals[finish]; ok {
+		if _, ok := places[finish]; !ok {
+			err.Add(NewIssuef(RuleFinishPlaceInPlaces, "finish place is not found in places").WithPlaces(finish))
+		}
+	}
+
+	outcomes, ok := c.(cfg.OutcomesInterface)
+	if !ok {
+		return PrepareResultErr(err)
+	}
+
+	names := make([]string, 0)
+	for name := range outcomes.GetOutcomes() {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		place := outcomes.GetOutcomes()[name].GetID()
+		if _, ok := places[place]; !ok {
+			err.Add(NewIssuef(
+				RuleFinishPlaceInPlaces,
+				"place with id '%s' of outcome '%s' is not found in places",
+				place,
+				name,
+			).WithPlaces(place))
+		}
+	}
+
+	return PrepareResultErr(err)
 }
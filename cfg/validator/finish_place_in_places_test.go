@@ -35,5 +35,57 @@ func TestFinishPlaceInPlaces_Validate_NotValidCfg_ExpectedErr(t *testing.T) {
 
 	v := NewFinishPlaceInPlaces()
 
-	assert.Equal(t, BuildErrorf("finish place is not found in places"), v.Validate(minCfg))
+	assert.Equal(
+		t,
+		BuildError(NewIssuef(
+			RuleFinishPlaceInPlaces,
+			"finish place is not found in places",
+		).WithPlaces("a")),
+		v.Validate(minCfg),
+	)
+}
+
+func TestFinishPlaceInPlaces_Validate_CfgWithOutcomes(t *testing.T) {
+	notValidErr := BuildError(NewIssuef(RuleFinishPlaceInPlaces, "finish place is not found in places").WithPlaces("a"))
+	notValidErr.Add(NewIssuef(
+		RuleFinishPlaceInPlaces,
+		"place with id 'c' of outcome 'rejected' is not found in places",
+	).WithPlaces("c"))
+
+	type data struct {
+		cfg      cfg.Interface
+		expected error
+	}
+
+	dp := map[string]data{
+		"valid": {
+			cfg: cfg.Minimal{
+				Finish:   "a",
+				Places:   []cfg.StringID{"a", "b"},
+				Outcomes: map[string]cfg.StringID{"approved": "a", "rejected": "b"},
+			},
+		},
+		"valid without finish": {
+			cfg: cfg.Minimal{
+				Places:   []cfg.StringID{"a", "b"},
+				Outcomes: map[string]cfg.StringID{"approved": "a", "rejected": "b"},
+			},
+		},
+		"not valid": {
+			cfg: cfg.Minimal{
+				Finish:   "a",
+				Places:   []cfg.StringID{"b"},
+				Outcomes: map[string]cfg.StringID{"approved": "b", "rejected": "c"},
+			},
+			expected: notValidErr,
+		},
+	}
+
+	v := NewFinishPlaceInPlaces()
+
+	for desc, d := range dp {
+		t.Run(desc, func(t *testing.T) {
+			assert.Equal(t, d.expected, v.Validate(d.cfg)) // nolint:scopelint
+		})
+	}
 }
@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/cfg/analysis"
+)
+
+const (
+	RuleFreeChoice         Rule = "freeChoice"
+	RuleExtendedFreeChoice Rule = "extendedFreeChoice"
+)
+
+// FreeChoice checks that config is free-choice or extended free-choice net, see analysis pkg.
+// It is optional, it isn't in NewCombinedWithAllValidators.
+type FreeChoice struct {
+	extended bool
+}
+
+// NewFreeChoice init validator of free-choice nets.
+func NewFreeChoice() *FreeChoice {
+	return &FreeChoice{}
+}
+
+// NewExtendedFreeChoice init validator of extended free-choice nets.
+func NewExtendedFreeChoice() *FreeChoice {
+	return &FreeChoice{extended: true}
+}
+
+func (f *FreeChoice) Validate(c cfg.Interface) error {
+	rule := RuleFreeChoice
+	conflicts := analysis.FreeChoiceConflicts
+
+	if f.extended {
+		rule = RuleExtendedFreeChoice
+		conflicts = analysis.ExtendedFreeChoiceConflicts
+	}
+
+	err := NewError()
+
+	for _, conflict := range conflicts(c) {
+		err.Add(NewIssuef(
+			rule,
+			"transitions with id '%s' and '%s' share place with id '%s', but have different input places",
+			conflict.Transitions[0],
+			conflict.Transitions[1],
+			conflict.Place,
+		).WithPlaces(conflict.Place).WithTransitions(conflict.Transitions...))
+	}
+
+	return PrepareResultErr(err)
+}
@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/andrskom/gowfnet/cfg"
+)
+
+var notFreeChoiceCfg = cfg.Minimal{ // nolint:gochecknoglobals
+	Start:  "start",
+	Finish: "finish",
+	Places: []cfg.StringID{"start", "a", "b", "finish"},
+	Transitions: cfg.MinimalTransitionRegistry{
+		"split": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"a", "b"}},
+		"t1":    {From: []cfg.StringID{"a", "b"}, To: []cfg.StringID{"finish"}},
+		"t2":    {From: []cfg.StringID{"a", "b"}, To: []cfg.StringID{"finish"}},
+	},
+}
+
+func TestFreeChoice_Validate_NotFreeChoice_ExpectedErr(t *testing.T) {
+	assert.Equal(
+		t,
+		BuildError(
+			NewIssuef(
+				RuleFreeChoice,
+				"transitions with id 't1' and 't2' share place with id 'a', but have different input places",
+			).WithPlaces("a").WithTransitions("t1", "t2"),
+			NewIssuef(
+				RuleFreeChoice,
+				"transitions with id 't1' and 't2' share place with id 'b', but have different input places",
+			).WithPlaces("b").WithTransitions("t1", "t2"),
+		),
+		NewFreeChoice().Validate(notFreeChoiceCfg),
+	)
+}
+
+func TestFreeChoice_Validate_ExtendedFreeChoice_NoErr(t *testing.T) {
+	assert.NoError(t, NewExtendedFreeChoice().Validate(notFreeChoiceCfg))
+}
+
+func TestFreeChoice_Validate_NotExtendedFreeChoice_ExpectedErr(t *testing.T) {
+	c := cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "a", "b", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"split": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"a", "b"}},
+			"t1":    {From: []cfg.StringID{"a"}, To: []cfg.StringID{"finish"}},
+			"t2":    {From: []cfg.StringID{"a", "b"}, To: []cfg.StringID{"finish"}},
+		},
+	}
+
+	assert.Equal(
+		t,
+		BuildError(NewIssuef(
+			RuleExtendedFreeChoice,
+			"transitions with id 't1' and 't2' share place with id 'a', but have different input places",
+		).WithPlaces("a").WithTransitions("t1", "t2")),
+		NewExtendedFreeChoice().Validate(c),
+	)
+}
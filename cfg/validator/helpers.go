@@ -1,6 +1,25 @@
 package validator
 
-import "github.com/andrskom/gowfnet/cfg"
+import (
+	"reflect"
+
+	"github.com/andrskom/gowfnet/cfg"
+)
+
+// isNil returns true for nil config and for typed nil, e.g. (*cfg.Minimal)(nil).
+func isNil(c cfg.Interface) bool {
+	if c == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(c)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
 
 func buildPlaceRegistryFromTransitions(tr cfg.TransitionRegistryInterface) map[string]struct{} {
 	res := make(map[string]struct{})
@@ -13,6 +32,10 @@ func buildPlaceRegistryFromTransitions(tr cfg.TransitionRegistryInterface) map[s
 		for _, place := range transition.GetTo() {
 			res[place.GetID()] = struct{}{}
 		}
+
+		if errPlace := cfg.GetErrorPlace(transition); errPlace != nil {
+			res[errPlace.GetID()] = struct{}{}
+		}
 	}
 
 	return res
@@ -0,0 +1,31 @@
+package validator
+
+import (
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/cfg/analysis"
+)
+
+const RuleMarkedGraph Rule = "markedGraph"
+
+// MarkedGraph checks that each place has exactly one input and one output transition.
+// It is optional, it isn't in NewCombinedWithAllValidators.
+type MarkedGraph struct {
+}
+
+func NewMarkedGraph() *MarkedGraph {
+	return &MarkedGraph{}
+}
+
+func (m *MarkedGraph) Validate(c cfg.Interface) error {
+	err := NewError()
+
+	for _, place := range analysis.NonMarkedGraphPlaces(c) {
+		err.Add(NewIssuef(
+			RuleMarkedGraph,
+			"place with id '%s' must have one input and one output transition",
+			place,
+		).WithPlaces(place))
+	}
+
+	return PrepareResultErr(err)
+}
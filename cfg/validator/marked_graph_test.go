@@ -0,0 +1,38 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/andrskom/gowfnet/cfg"
+)
+
+func TestMarkedGraph_Validate_NotValidCfg_ExpectedErr(t *testing.T) {
+	assert.Equal(
+		t,
+		BuildError(
+			NewIssuef(RuleMarkedGraph, "place with id 'a' must have one input and one output transition").
+				WithPlaces("a"),
+			NewIssuef(RuleMarkedGraph, "place with id 'b' must have one input and one output transition").
+				WithPlaces("b"),
+			NewIssuef(RuleMarkedGraph, "place with id 'finish' must have one input and one output transition").
+				WithPlaces("finish"),
+			NewIssuef(RuleMarkedGraph, "place with id 'start' must have one input and one output transition").
+				WithPlaces("start"),
+		),
+		NewMarkedGraph().Validate(notFreeChoiceCfg),
+	)
+}
+
+func TestMarkedGraph_Validate_CorrectCfg_NoErr(t *testing.T) {
+	assert.NoError(t, NewMarkedGraph().Validate(cfg.Minimal{
+		Start:  "a",
+		Finish: "b",
+		Places: []cfg.StringID{"a", "b"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"t1": {From: []cfg.StringID{"a"}, To: []cfg.StringID{"b"}},
+			"t2": {From: []cfg.StringID{"b"}, To: []cfg.StringID{"a"}},
+		},
+	}))
+}
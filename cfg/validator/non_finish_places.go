@@ -7,6 +7,7 @@ import (
 	"github.com/andrskom/gowfnet/cfg"
 )
 
+// NonFinishPlaces checks that the finish place or a place of an outcome is reachable from each place.
 type NonFinishPlaces struct {
 	treeBuilder TreeBuilder
 }
@@ -21,13 +22,15 @@ func (n *NonFinishPlaces) Validate(c cfg.Interface) error {
 		return err
 	}
 
-	node, err := tree.GetFinishNode()
+	nodes, err := tree.GetTerminalNodes()
 	if err != nil {
 		return err
 	}
 
-	if err := n.dfsNodeProcessor(node); err != nil {
-		return err
+	for _, node := range nodes {
+		if err := n.dfsNodeProcessor(node); err != nil {
+			return err
+		}
 	}
 
 	vErr := NewError()
@@ -36,9 +39,17 @@ func (n *NonFinishPlaces) Validate(c cfg.Interface) error {
 		switch node.GetColor() {
 		case ColorBlack:
 		case ColorGray:
-			vErr.Addf("unexpected situation for non-finish places validator in place with id '%s'", node.GetID())
+			vErr.Add(NewIssuef(
+				RuleNonFinishPlaces,
+				"unexpected situation for non-finish places validator in place with id '%s'",
+				node.GetID(),
+			).WithPlaces(node.GetID()))
 		case ColorWhite:
-			vErr.Addf("place with id '%s' is non-finish place", node.GetID())
+			vErr.Add(NewIssuef(
+				RuleNonFinishPlaces,
+				"place with id '%s' is non-finish place",
+				node.GetID(),
+			).WithPlaces(node.GetID()))
 		default:
 			return errors.New("unexpected color of node")
 		}
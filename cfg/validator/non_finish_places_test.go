@@ -66,7 +66,10 @@ func TestNonFinishPlaces_Validate_NotValidCfg_ExpectedErr(t *testing.T) {
 					},
 				},
 			},
-			expected: BuildErrorf("place with id 'a' is non-finish place"),
+			expected: BuildError(NewIssuef(
+				RuleNonFinishPlaces,
+				"place with id 'a' is non-finish place",
+			).WithPlaces("a")),
 		},
 		"net with branch error": {
 			cfg: cfg.Minimal{
@@ -84,7 +87,31 @@ func TestNonFinishPlaces_Validate_NotValidCfg_ExpectedErr(t *testing.T) {
 					},
 				},
 			},
-			expected: BuildErrorf("place with id 'c' is non-finish place"),
+			expected: BuildError(NewIssuef(
+				RuleNonFinishPlaces,
+				"place with id 'c' is non-finish place",
+			).WithPlaces("c")),
+		},
+		"net with outcomes": {
+			cfg: cfg.Minimal{
+				Start:    "a",
+				Places:   []cfg.StringID{"a", "b", "r"},
+				Outcomes: map[string]cfg.StringID{"rejected": "r"},
+				Transitions: map[string]cfg.MinimalTransition{
+					"t1": {
+						From: []cfg.StringID{"a"},
+						To:   []cfg.StringID{"r"},
+					},
+					"t2": {
+						From: []cfg.StringID{"a"},
+						To:   []cfg.StringID{"b"},
+					},
+				},
+			},
+			expected: BuildError(NewIssuef(
+				RuleNonFinishPlaces,
+				"place with id 'b' is non-finish place",
+			).WithPlaces("b")),
 		},
 	}
 
@@ -133,6 +160,45 @@ func TestNonFinishPlaces_Validate_ValidCfg_NoErr(t *testing.T) {
 				},
 			},
 		},
+		"net with outcomes": {
+			cfg: cfg.Minimal{
+				Start:    "a",
+				Finish:   "z",
+				Places:   []cfg.StringID{"a", "b", "z", "r"},
+				Outcomes: map[string]cfg.StringID{"approved": "z", "rejected": "r"},
+				Transitions: map[string]cfg.MinimalTransition{
+					"t1": {
+						From: []cfg.StringID{"a"},
+						To:   []cfg.StringID{"b"},
+					},
+					"t2": {
+						From: []cfg.StringID{"b"},
+						To:   []cfg.StringID{"z"},
+					},
+					"t3": {
+						From: []cfg.StringID{"a"},
+						To:   []cfg.StringID{"r"},
+					},
+				},
+			},
+		},
+		"net with outcomes without finish": {
+			cfg: cfg.Minimal{
+				Start:    "a",
+				Places:   []cfg.StringID{"a", "y", "n"},
+				Outcomes: map[string]cfg.StringID{"approved": "y", "rejected": "n"},
+				Transitions: map[string]cfg.MinimalTransition{
+					"t1": {
+						From: []cfg.StringID{"a"},
+						To:   []cfg.StringID{"y"},
+					},
+					"t2": {
+						From: []cfg.StringID{"a"},
+						To:   []cfg.StringID{"n"},
+					},
+				},
+			},
+		},
 		"net with cycle": {
 			cfg: cfg.Minimal{
 				Start:  "a",
@@ -12,12 +12,12 @@ func NewNotNil() *NotNil {
 }
 
 func (n *NotNil) Validate(c cfg.Interface) error {
-	if c != nil {
+	if !isNil(c) {
 		return nil
 	}
 
 	err := NewError()
-	err.Addf("config of net can't be nil")
+	err.Add(NewIssuef(RuleNotNil, "config of net can't be nil"))
 
 	return err
 }
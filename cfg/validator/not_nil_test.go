@@ -21,5 +21,11 @@ func TestNotNil_Validate_ValidCfg_NoErr(t *testing.T) {
 func TestNotNil_Validate_NotValidCfg_ExpectedErr(t *testing.T) {
 	v := NewNotNil()
 
-	assert.Equal(t, BuildErrorf("config of net can't be nil"), v.Validate(nil))
+	assert.Equal(t, BuildError(NewIssuef(RuleNotNil, "config of net can't be nil")), v.Validate(nil))
+}
+
+func TestNotNil_Validate_TypedNilCfg_ExpectedErr(t *testing.T) {
+	v := NewNotNil()
+
+	assert.Equal(t, BuildError(NewIssuef(RuleNotNil, "config of net can't be nil")), v.Validate((*cfg.Minimal)(nil)))
 }
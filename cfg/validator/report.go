@@ -0,0 +1,37 @@
+package validator
+
+import "time"
+
+// Timing is duration of run of validator.
+type Timing struct {
+	Validator string        `json:"validator"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// Report is the result of all validators of Combined.
+type Report struct {
+	Issues  []Issue  `json:"issues"`
+	Timings []Timing `json:"timings"`
+}
+
+// NewReport init empty report.
+func NewReport() *Report {
+	return &Report{
+		Issues:  make([]Issue, 0),
+		Timings: make([]Timing, 0),
+	}
+}
+
+// HasErrors returns true if the report has issues with error severity.
+func (r *Report) HasErrors() bool {
+	return BuildError(r.Issues...).HasErrors()
+}
+
+// Err returns *Error with all issues if the report has issues with error severity, otherwise nil.
+func (r *Report) Err() error {
+	if !r.HasErrors() {
+		return nil
+	}
+
+	return BuildError(r.Issues...)
+}
@@ -0,0 +1,34 @@
+package validator
+
+import (
+	"strings"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/cfg/analysis"
+)
+
+const RuleSiphons Rule = "siphons"
+
+// Siphons checks Commoner's condition, each siphon of the short-circuited config must contain a marked trap,
+// see analysis.SiphonsWithoutMarkedTrap. For free-choice nets it means absence of deadlocks.
+// It is optional, it isn't in NewCombinedWithAllValidators.
+type Siphons struct {
+}
+
+func NewSiphons() *Siphons {
+	return &Siphons{}
+}
+
+func (s *Siphons) Validate(c cfg.Interface) error {
+	err := NewError()
+
+	for _, siphon := range analysis.SiphonsWithoutMarkedTrap(c) {
+		err.Add(NewIssuef(
+			RuleSiphons,
+			"siphon with places '%s' doesn't contain marked trap",
+			strings.Join(siphon, "', '"),
+		).WithPlaces(siphon...))
+	}
+
+	return PrepareResultErr(err)
+}
@@ -0,0 +1,31 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/andrskom/gowfnet/cfg"
+)
+
+func TestSiphons_Validate_NotValidCfg_ExpectedErr(t *testing.T) {
+	c := cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "a", "b", "x", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"split": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"a", "b"}},
+			"join":  {From: []cfg.StringID{"a", "b", "x"}, To: []cfg.StringID{"finish"}},
+		},
+	}
+
+	assert.Equal(
+		t,
+		BuildError(NewIssuef(RuleSiphons, "siphon with places 'x' doesn't contain marked trap").WithPlaces("x")),
+		NewSiphons().Validate(c),
+	)
+}
+
+func TestSiphons_Validate_CorrectCfg_NoErr(t *testing.T) {
+	assert.NoError(t, NewSiphons().Validate(reworkCfg))
+}
@@ -17,7 +17,7 @@ func (s *StartPlaceInPlaces) Validate(c cfg.Interface) error {
 	}
 
 	err := NewError()
-	err.Addf("start place is not found in places")
+	err.Add(NewIssuef(RuleStartPlaceInPlaces, "start place is not found in places").WithPlaces(c.GetStart().GetID()))
 
 	return err
 }
@@ -35,5 +35,12 @@ func TestStartPlaceInPlaces_Validate_NotValidCfg_ExpectedErr(t *testing.T) {
 
 	v := NewStartPlaceInPlaces()
 
-	assert.Equal(t, BuildErrorf("start place is not found in places"), v.Validate(minCfg))
+	assert.Equal(
+		t,
+		BuildError(NewIssuef(
+			RuleStartPlaceInPlaces,
+			"start place is not found in places",
+		).WithPlaces("a")),
+		v.Validate(minCfg),
+	)
 }
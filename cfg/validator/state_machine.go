@@ -0,0 +1,31 @@
+package validator
+
+import (
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/cfg/analysis"
+)
+
+const RuleStateMachine Rule = "stateMachine"
+
+// StateMachine checks that each transition has exactly one input and one output place.
+// It is optional, it isn't in NewCombinedWithAllValidators.
+type StateMachine struct {
+}
+
+func NewStateMachine() *StateMachine {
+	return &StateMachine{}
+}
+
+func (s *StateMachine) Validate(c cfg.Interface) error {
+	err := NewError()
+
+	for _, transition := range analysis.NonStateMachineTransitions(c) {
+		err.Add(NewIssuef(
+			RuleStateMachine,
+			"transition with id '%s' must have one input and one output place",
+			transition,
+		).WithTransitions(transition))
+	}
+
+	return PrepareResultErr(err)
+}
@@ -0,0 +1,26 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateMachine_Validate_NotValidCfg_ExpectedErr(t *testing.T) {
+	assert.Equal(
+		t,
+		BuildError(
+			NewIssuef(RuleStateMachine, "transition with id 'split' must have one input and one output place").
+				WithTransitions("split"),
+			NewIssuef(RuleStateMachine, "transition with id 't1' must have one input and one output place").
+				WithTransitions("t1"),
+			NewIssuef(RuleStateMachine, "transition with id 't2' must have one input and one output place").
+				WithTransitions("t2"),
+		),
+		NewStateMachine().Validate(notFreeChoiceCfg),
+	)
+}
+
+func TestStateMachine_Validate_CorrectCfg_NoErr(t *testing.T) {
+	assert.NoError(t, NewStateMachine().Validate(reworkCfg))
+}
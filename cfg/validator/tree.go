@@ -2,6 +2,7 @@ package validator
 
 import (
 	"errors"
+	"sort"
 
 	"github.com/andrskom/gowfnet/cfg"
 )
@@ -13,9 +14,10 @@ var (
 )
 
 type Tree struct {
-	startNodeID  string
-	finishNodeID string
-	registry     map[string]*TreeNode
+	startNodeID    string
+	finishNodeID   string
+	outcomeNodeIDs []string
+	registry       map[string]*TreeNode
 }
 
 func NewTree(startNodeID string, finishNodeID string) *Tree {
@@ -38,6 +40,33 @@ func (t *Tree) GetFinishNode() (*TreeNode, error) {
 	return t.GetNode(t.finishNodeID)
 }
 
+// AddOutcomeNode marks the node as terminal node of an outcome.
+func (t *Tree) AddOutcomeNode(nodeID string) {
+	t.outcomeNodeIDs = append(t.outcomeNodeIDs, nodeID)
+}
+
+// GetTerminalNodes returns the finish node and nodes of outcomes.
+// The finish node is skipped if it is empty and the tree has nodes of outcomes.
+func (t *Tree) GetTerminalNodes() ([]*TreeNode, error) {
+	ids := t.outcomeNodeIDs
+	if t.finishNodeID != "" || len(ids) == 0 {
+		ids = append([]string{t.finishNodeID}, ids...)
+	}
+
+	res := make([]*TreeNode, 0, len(ids))
+
+	for _, id := range ids {
+		node, err := t.GetNode(id)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, node)
+	}
+
+	return res, nil
+}
+
 func (t *Tree) GetNode(nodeID string) (*TreeNode, error) {
 	res, ok := t.registry[nodeID]
 	if !ok {
@@ -140,6 +169,20 @@ func (b *CfgTreeBuilder) Build(c cfg.Interface) (*Tree, error) {
 		tree.AddNode(NewTreeNode(place.GetID()))
 	}
 
+	outcomePlaces := make([]string, 0)
+
+	for place := range cfg.GetTerminalPlaces(c) {
+		if place != c.GetFinish().GetID() {
+			outcomePlaces = append(outcomePlaces, place)
+		}
+	}
+
+	sort.Strings(outcomePlaces)
+
+	for _, place := range outcomePlaces {
+		tree.AddOutcomeNode(place)
+	}
+
 	for _, tr := range c.GetTransitions().GetAsMap() {
 		for _, f := range tr.GetFrom() {
 			fromNode, err := tree.GetNode(f.GetID())
@@ -147,7 +190,7 @@ func (b *CfgTreeBuilder) Build(c cfg.Interface) (*Tree, error) {
 				return nil, err
 			}
 
-			for _, t := range tr.GetTo() {
+			for _, t := range buildTargets(tr) {
 				toNode, err := tree.GetNode(t.GetID())
 				if err != nil {
 					return nil, err
@@ -162,6 +205,16 @@ func (b *CfgTreeBuilder) Build(c cfg.Interface) (*Tree, error) {
 	return tree, nil
 }
 
+// buildTargets returns output places of the transition and its error place, if it is set.
+func buildTargets(tr cfg.TransitionInterface) []cfg.IDGetter {
+	errPlace := cfg.GetErrorPlace(tr)
+	if errPlace == nil {
+		return tr.GetTo()
+	}
+
+	return append(tr.GetTo(), errPlace)
+}
+
 type NodeStack struct {
 	stack []*TreeNode
 }
@@ -71,6 +71,43 @@ func TestTree_GetFinishNode_ConfiguredWithoutFinish_ExpectedErr(t *testing.T) {
 	assert.Equal(t, ErrNodeIsNotFound, err)
 }
 
+func TestTree_GetTerminalNodes_WithOutcomes_ExpectedNodes(t *testing.T) {
+	tree := NewTree("a", "z")
+	tree.AddOutcomeNode("y")
+
+	finishNode := NewTreeNode("z")
+	tree.AddNode(finishNode)
+
+	outcomeNode := NewTreeNode("y")
+	tree.AddNode(outcomeNode)
+
+	nodes, err := tree.GetTerminalNodes()
+	assert.NoError(t, err)
+	assert.Equal(t, []*TreeNode{finishNode, outcomeNode}, nodes)
+}
+
+func TestTree_GetTerminalNodes_OutcomesWithoutFinish_ExpectedNodes(t *testing.T) {
+	tree := NewTree("a", "")
+	tree.AddOutcomeNode("y")
+
+	outcomeNode := NewTreeNode("y")
+	tree.AddNode(outcomeNode)
+
+	nodes, err := tree.GetTerminalNodes()
+	assert.NoError(t, err)
+	assert.Equal(t, []*TreeNode{outcomeNode}, nodes)
+}
+
+func TestTree_GetTerminalNodes_ConfiguredWithoutOutcome_ExpectedErr(t *testing.T) {
+	tree := NewTree("a", "z")
+	tree.AddNode(NewTreeNode("z"))
+	tree.AddOutcomeNode("y")
+
+	nodes, err := tree.GetTerminalNodes()
+	assert.Nil(t, nodes)
+	assert.Equal(t, ErrNodeIsNotFound, err)
+}
+
 func TestTree_GetNode_Configured_ExpectedNode(t *testing.T) {
 	tree := NewTree("a", "z")
 
@@ -229,6 +266,34 @@ func TestCfgTreeBuilder_Build_CorrectCfg_ExpectedTree(t *testing.T) {
 	assert.Equal(t, map[string]*TreeNode{"a": startNode}, finishNode.GetFrom())
 }
 
+func TestCfgTreeBuilder_Build_CfgWithOutcomes_ExpectedTerminalNodes(t *testing.T) {
+	minCfg := &cfg.Minimal{
+		Start:    "a",
+		Finish:   "b",
+		Places:   []cfg.StringID{"a", "b", "c"},
+		Outcomes: map[string]cfg.StringID{"approved": "b", "rejected": "c"},
+		Transitions: map[string]cfg.MinimalTransition{
+			"d": {
+				From: []cfg.StringID{"a"},
+				To:   []cfg.StringID{"b"},
+			},
+			"e": {
+				From: []cfg.StringID{"a"},
+				To:   []cfg.StringID{"c"},
+			},
+		},
+	}
+
+	tree, err := NewCfgTreeBuilder().Build(minCfg)
+	require.NoError(t, err)
+
+	nodes, err := tree.GetTerminalNodes()
+	require.NoError(t, err)
+	require.Len(t, nodes, 2)
+	assert.Equal(t, "b", nodes[0].GetID())
+	assert.Equal(t, "c", nodes[1].GetID())
+}
+
 func TestCfgTreeBuilder_Build_IncorrectCfg_ExpectedErr(t *testing.T) {
 	dp := map[string]cfg.Minimal{
 		"unexpected in from": {
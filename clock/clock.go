@@ -0,0 +1,61 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a source of the current time.
+// Components which depend on time use it instead of time.Now to be testable.
+type Clock interface {
+	Now() time.Time
+}
+
+// System is a clock based on time.Now.
+type System struct{}
+
+// NewSystem init system clock.
+func NewSystem() *System {
+	return &System{}
+}
+
+// Now returns current local time.
+func (s *System) Now() time.Time {
+	return time.Now()
+}
+
+// Fake is a clock which is moved manually.
+// Use it in tests.
+type Fake struct {
+	now time.Time
+	mu  sync.Mutex
+}
+
+// NewFake init fake clock with the time.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns current time of the clock.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+// Set current time of the clock.
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = now
+}
+
+// Add duration to current time of the clock.
+func (f *Fake) Add(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.now = f.now.Add(d)
+}
@@ -0,0 +1,38 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystem_Now(t *testing.T) {
+	before := time.Now()
+	now := NewSystem().Now()
+
+	assert.False(t, now.Before(before))
+	assert.False(t, now.After(time.Now()))
+}
+
+func TestFake_Now_NotMoved_ReturnsInitTime(t *testing.T) {
+	now := time.Date(2020, 10, 4, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, now, NewFake(now).Now())
+}
+
+func TestFake_Set(t *testing.T) {
+	c := NewFake(time.Date(2020, 10, 4, 0, 0, 0, 0, time.UTC))
+
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.Set(now)
+
+	assert.Equal(t, now, c.Now())
+}
+
+func TestFake_Add(t *testing.T) {
+	c := NewFake(time.Date(2020, 10, 4, 0, 0, 0, 0, time.UTC))
+	c.Add(48 * time.Hour)
+
+	assert.Equal(t, time.Date(2020, 10, 6, 0, 0, 0, 0, time.UTC), c.Now())
+}
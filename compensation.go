@@ -0,0 +1,150 @@
+package gowfnet
+
+import (
+	"context"
+
+	"github.com/andrskom/gowfnet/state"
+)
+
+// HistoryStateInterface is a state which records history of transitions, e.g. state.State.
+type HistoryStateInterface interface {
+	StateInterface
+	GetHistory() []state.HistoryEntry
+	RevertLast(ctx context.Context) (state.HistoryEntry, error)
+	MarkLastCompensated() error
+}
+
+// CompensationHandler undoes side effects of the transition.
+// Ctx contains the transition id, see state.GetTransitionID.
+//
+// Handlers must be idempotent: the handler isn't called again after it succeeded,
+// but the state with the mark can be lost, e.g. if it isn't saved after the call.
+type CompensationHandler func(ctx context.Context, transitionID string, s StateOpInterface) error
+
+// compensationKey is the transition id and the name of the subprocess config, it is empty for the net.
+type compensationKey struct {
+	subprocess   string
+	transitionID string
+}
+
+// WithCompensation set compensation handler of the transition, the previous handler is replaced.
+// Handlers of transitions of subprocesses are set by WithSubprocessCompensation.
+func (n *Net) WithCompensation(transitionID string, handler CompensationHandler) {
+	n.setCompensation(compensationKey{subprocess: n.subprocess, transitionID: transitionID}, handler)
+}
+
+// WithSubprocessCompensation set compensation handler of the transition of the subprocess,
+// subprocess is the name of the config in the registry. The previous handler is replaced.
+func (n *Net) WithSubprocessCompensation(subprocess string, transitionID string, handler CompensationHandler) {
+	n.setCompensation(compensationKey{subprocess: subprocess, transitionID: transitionID}, handler)
+}
+
+func (n *Net) setCompensation(key compensationKey, handler CompensationHandler) {
+	if n.compensations == nil {
+		n.compensations = make(map[compensationKey]CompensationHandler)
+	}
+
+	n.compensations[key] = handler
+}
+
+// Compensate transitions from the history of the state in reverse order.
+//
+// For each transition the compensation handler is called if it is set and the marking is moved back.
+// It works for error state too, e.g. after failure of BeforeTransition of a late transition.
+// Compensation is stopped on the first failure, the err is added to the err stack of the state and returned,
+// so transitions which are left in the history are not compensated.
+// The entry is marked as compensated after the handler succeeded, see state.HistoryEntry,
+// so the next call doesn't call the handler again if moving of the marking back failed.
+//
+// Subprocesses are compensated by nets of their configs with handlers set by WithSubprocessCompensation.
+// Running subprocesses are compensated first and removed, because their transitions are the latest.
+// The state of a completed subprocess is kept in the history entry of its transition, see state.HistoryEntry,
+// it is compensated before the handler of the transition.
+func (n *Net) Compensate(ctx context.Context, s StateInterface) error {
+	historyState, ok := s.(HistoryStateInterface)
+	if !ok {
+		return state.NewError(state.ErrCodeNetStateDoesntSupportHistory, "State doesn't support history")
+	}
+
+	if n.listener.HasStateListener() {
+		s.WithListener(n.listener.GetStateListener())
+	}
+
+	if err := n.compensateRunningSubprocesses(ctx, s); err != nil {
+		s.AddError(err)
+
+		return err
+	}
+
+	for {
+		history := historyState.GetHistory()
+		if len(history) == 0 {
+			return nil
+		}
+
+		entry := history[len(history)-1]
+		trCtx := state.WithTransitionID(ctx, entry.TransitionID)
+
+		if err := n.compensate(trCtx, historyState, entry); err != nil {
+			s.AddError(err)
+
+			return err
+		}
+	}
+}
+
+func (n *Net) compensate(ctx context.Context, s HistoryStateInterface, entry state.HistoryEntry) error {
+	if entry.Subprocess != nil {
+		subNet, err := n.getSubprocessNet(entry.TransitionID)
+		if err != nil {
+			return err
+		}
+
+		if err := subNet.Compensate(ctx, entry.Subprocess); err != nil {
+			return err
+		}
+	}
+
+	handler, ok := n.compensations[compensationKey{subprocess: n.subprocess, transitionID: entry.TransitionID}]
+	if ok && !entry.Compensated {
+		if err := handler(ctx, entry.TransitionID, s); err != nil {
+			return err
+		}
+
+		if err := s.MarkLastCompensated(); err != nil {
+			return err
+		}
+	}
+
+	_, err := s.RevertLast(ctx)
+
+	return err
+}
+
+func (n *Net) compensateRunningSubprocesses(ctx context.Context, s StateInterface) error {
+	nested, ok := s.(NestedStateInterface)
+	if !ok {
+		return nil
+	}
+
+	for _, id := range n.getSubprocessTransitionIDs() {
+		child, ok := nested.GetChild(id)
+		if !ok {
+			continue
+		}
+
+		subNet, err := n.getSubprocessNet(id)
+		if err != nil {
+			return err
+		}
+
+		if err := subNet.Compensate(ctx, child); err != nil {
+			return err
+		}
+
+		nested.RemoveChild(id)
+		n.notifySubprocessRemoval(ctx, nested, id)
+	}
+
+	return nil
+}
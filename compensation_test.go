@@ -0,0 +1,263 @@
+package gowfnet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/state"
+)
+
+func newSagaNet(t *testing.T) (*Net, *state.State, *[]string) {
+	net := NewNet(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "reserved", "charged", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"reserve": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"reserved"}},
+			"charge":  {From: []cfg.StringID{"reserved"}, To: []cfg.StringID{"charged"}},
+			"ship":    {From: []cfg.StringID{"charged"}, To: []cfg.StringID{"finish"}},
+		},
+	})
+
+	compensated := make([]string, 0)
+
+	for _, id := range []string{"reserve", "charge"} {
+		net.WithCompensation(id, func(ctx context.Context, transitionID string, s StateOpInterface) error {
+			ctxTransitionID, _ := state.GetTransitionID(ctx)
+			compensated = append(compensated, transitionID+":"+ctxTransitionID)
+
+			return nil
+		})
+	}
+
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+	require.NoError(t, net.Transit(context.Background(), st, "reserve"))
+	require.NoError(t, net.Transit(context.Background(), st, "charge"))
+
+	return net, st, &compensated
+}
+
+func TestNet_Compensate_HandlersAreCalledInReverseOrder(t *testing.T) {
+	net, st, compensated := newSagaNet(t)
+
+	require.NoError(t, net.Compensate(context.Background(), st))
+	assert.Equal(t, []string{"charge:charge", "reserve:reserve"}, *compensated)
+	assert.Equal(t, []string{"start"}, st.GetPlaces())
+	assert.Empty(t, st.GetHistory())
+	assert.False(t, st.IsError())
+}
+
+func TestNet_Compensate_HandlerErr_ErrIsAddedAndCompensationIsStopped(t *testing.T) {
+	net, st, compensated := newSagaNet(t)
+
+	eErr := errors.New("refund failed")
+	net.WithCompensation("charge", func(ctx context.Context, transitionID string, s StateOpInterface) error {
+		return eErr
+	})
+
+	err := net.Compensate(context.Background(), st)
+	assert.Same(t, eErr, err)
+	assert.Empty(t, *compensated)
+	assert.Equal(t, []string{"charged"}, st.GetPlaces())
+	assert.True(t, st.IsError())
+	assert.Equal(t, "refund failed", st.GetErrorStack().GetErrs()[0].GetMessage())
+}
+
+func TestNet_Compensate_RevertErr_ErrIsAdded(t *testing.T) {
+	net, st, _ := newSagaNet(t)
+	_, err := st.CancelTokens(context.Background(), []string{"charged"})
+	require.NoError(t, err)
+
+	err = net.Compensate(context.Background(), st)
+	assert.True(t, state.ErrorIs(state.ErrCodeStateHasNotTokenInPlace, err))
+	assert.True(t, st.IsError())
+}
+
+func TestNet_Compensate_StateWithoutHistory_ExpectedErr(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	net, _, _ := newSagaNet(t)
+
+	err := net.Compensate(context.Background(), NewMockStateInterface(ctrl))
+	assert.Equal(t, state.NewError(state.ErrCodeNetStateDoesntSupportHistory, "State doesn't support history"), err)
+}
+
+func newSubprocessSagaNet(t *testing.T) (*Net, *[]string) {
+	payment := cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "charged", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"charge":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"charged"}},
+			"receipt": {From: []cfg.StringID{"charged"}, To: []cfg.StringID{"finish"}},
+		},
+	}
+
+	registry := cfg.NewRegistry()
+	require.NoError(t, registry.AddWithName("payment", payment))
+
+	net := NewNet(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "reserved", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"reserve": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"reserved"}},
+			"pay":     {From: []cfg.StringID{"reserved"}, To: []cfg.StringID{"finish"}, Subprocess: "payment"},
+		},
+	})
+	net.WithRegistry(registry)
+
+	compensated := make([]string, 0)
+	handler := func(ctx context.Context, transitionID string, s StateOpInterface) error {
+		compensated = append(compensated, transitionID)
+
+		return nil
+	}
+
+	for _, id := range []string{"reserve", "pay"} {
+		net.WithCompensation(id, handler)
+	}
+
+	for _, id := range []string{"charge", "receipt"} {
+		net.WithSubprocessCompensation("payment", id, handler)
+	}
+
+	return net, &compensated
+}
+
+func TestNet_Compensate_CompletedSubprocess_ChildIsCompensated(t *testing.T) {
+	net, compensated := newSubprocessSagaNet(t)
+	ctx := context.Background()
+
+	st := state.NewState()
+	require.NoError(t, net.Start(ctx, st))
+	require.NoError(t, net.Transit(ctx, st, "reserve"))
+	require.NoError(t, net.Transit(ctx, st, "pay"))
+	require.NoError(t, net.TransitSubprocess(ctx, st, []string{"pay"}, "charge"))
+	require.NoError(t, net.TransitSubprocess(ctx, st, []string{"pay"}, "receipt"))
+	require.True(t, st.IsFinished())
+
+	child := st.GetHistory()[1].Subprocess
+	require.NotNil(t, child)
+
+	require.NoError(t, net.Compensate(ctx, st))
+	assert.Equal(t, []string{"receipt", "charge", "pay", "reserve"}, *compensated)
+	assert.Equal(t, []string{"start"}, st.GetPlaces())
+	assert.Equal(t, []string{"start"}, child.GetPlaces())
+	assert.Empty(t, child.GetHistory())
+}
+
+func TestNet_Compensate_RunningSubprocess_ChildIsCompensatedAndRemoved(t *testing.T) {
+	net, compensated := newSubprocessSagaNet(t)
+	ctx := context.Background()
+
+	st := state.NewState()
+	require.NoError(t, net.Start(ctx, st))
+	require.NoError(t, net.Transit(ctx, st, "reserve"))
+	require.NoError(t, net.Transit(ctx, st, "pay"))
+	require.NoError(t, net.TransitSubprocess(ctx, st, []string{"pay"}, "charge"))
+
+	require.NoError(t, net.Compensate(ctx, st))
+	assert.Equal(t, []string{"charge", "reserve"}, *compensated)
+	assert.Equal(t, []string{"start"}, st.GetPlaces())
+
+	_, ok := st.GetChild("pay")
+	assert.False(t, ok)
+}
+
+func TestNet_Compensate_SubprocessHandlerErr_ErrIsAddedToBothStates(t *testing.T) {
+	net, _ := newSubprocessSagaNet(t)
+	ctx := context.Background()
+	eErr := errors.New("refund failed")
+
+	net.WithSubprocessCompensation("payment", "charge", func(ctx context.Context, transitionID string, s StateOpInterface) error {
+		return eErr
+	})
+
+	st := state.NewState()
+	require.NoError(t, net.Start(ctx, st))
+	require.NoError(t, net.Transit(ctx, st, "reserve"))
+	require.NoError(t, net.Transit(ctx, st, "pay"))
+	require.NoError(t, net.TransitSubprocess(ctx, st, []string{"pay"}, "charge"))
+
+	child, ok := st.GetChild("pay")
+	require.True(t, ok)
+
+	err := net.Compensate(ctx, st)
+	assert.Equal(t, "refund failed", err.Error())
+	assert.True(t, st.IsError())
+	assert.True(t, child.IsError())
+	assert.Equal(t, []string{"reserved"}, st.GetPlaces())
+}
+
+func TestNet_Compensate_TheSameTransitionIDInSubprocess_HandlersAreSeparated(t *testing.T) {
+	review := cfg.Minimal{
+		Start:       "start",
+		Finish:      "finish",
+		Places:      []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{"approve": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}}},
+	}
+
+	registry := cfg.NewRegistry()
+	require.NoError(t, registry.AddWithName("review", review))
+
+	net := NewNet(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "reviewed", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"review":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"reviewed"}, Subprocess: "review"},
+			"approve": {From: []cfg.StringID{"reviewed"}, To: []cfg.StringID{"finish"}},
+		},
+	})
+	net.WithRegistry(registry)
+
+	compensated := make([]string, 0)
+
+	net.WithCompensation("approve", func(ctx context.Context, transitionID string, s StateOpInterface) error {
+		compensated = append(compensated, "main:"+transitionID)
+
+		return nil
+	})
+	net.WithSubprocessCompensation("review", "approve", func(ctx context.Context, transitionID string, s StateOpInterface) error {
+		compensated = append(compensated, "review:"+transitionID)
+
+		return nil
+	})
+
+	ctx := context.Background()
+	st := state.NewState()
+	require.NoError(t, net.Start(ctx, st))
+	require.NoError(t, net.Transit(ctx, st, "review"))
+	require.NoError(t, net.TransitSubprocess(ctx, st, []string{"review"}, "approve"))
+	require.NoError(t, net.Transit(ctx, st, "approve"))
+
+	require.NoError(t, net.Compensate(ctx, st))
+	assert.Equal(t, []string{"main:approve", "review:approve"}, compensated)
+}
+
+func TestNet_Compensate_RevertErr_HandlerIsNotCalledAgain(t *testing.T) {
+	net, st, compensated := newSagaNet(t)
+	ctx := context.Background()
+
+	_, err := st.CancelTokens(ctx, []string{"charged"})
+	require.NoError(t, err)
+
+	require.Error(t, net.Compensate(ctx, st))
+	assert.Equal(t, []string{"charge:charge"}, *compensated)
+	assert.True(t, st.GetHistory()[1].Compensated)
+
+	st.ResolveErrors("token is restored")
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(ctx, nil, []string{"charged"}))
+
+	require.NoError(t, net.Compensate(ctx, st))
+	assert.Equal(t, []string{"charge:charge", "reserve:reserve"}, *compensated)
+	assert.Equal(t, []string{"start"}, st.GetPlaces())
+}
@@ -0,0 +1,105 @@
+package gowfnet
+
+import (
+	"context"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/state"
+)
+
+// CompletionPolicy defines what the net does with tokens in other places
+// when a transition moves the token to a terminal place.
+type CompletionPolicy int
+
+const (
+	// CompletionPolicyLegacy finishes the state and keeps tokens in other places.
+	CompletionPolicyLegacy CompletionPolicy = iota
+	// CompletionPolicyStrict returns err if other places have tokens, the state isn't changed.
+	CompletionPolicyStrict
+	// CompletionPolicyLenient cancels tokens in other places before finishing.
+	// The state must implement CancellableStateInterface, listeners get the cancellation event.
+	CompletionPolicyLenient
+)
+
+// WithCompletionPolicy set completion policy to the net, CompletionPolicyLegacy is used by default.
+func (n *Net) WithCompletionPolicy(policy CompletionPolicy) {
+	n.completionPolicy = policy
+}
+
+// checkCompletion returns err if the move to the terminal place violates the policy.
+// It is called before any change of the state, so the state isn't changed on err.
+func (n *Net) checkCompletion(s StateInterface, fromPlaces []string, toPlaces []string, cancelPlaces []string) error {
+	if n.completionPolicy == CompletionPolicyLegacy || !n.isTerminal(toPlaces) {
+		return nil
+	}
+
+	removed := make(map[string]struct{}, len(fromPlaces)+len(cancelPlaces)+1)
+
+	for _, place := range fromPlaces {
+		removed[place] = struct{}{}
+	}
+
+	for _, place := range cancelPlaces {
+		removed[place] = struct{}{}
+	}
+
+	removed[toPlaces[0]] = struct{}{}
+
+	for _, place := range s.GetPlaces() {
+		if _, ok := removed[place]; ok {
+			continue
+		}
+
+		if n.completionPolicy == CompletionPolicyStrict {
+			return state.NewErrorf(
+				state.ErrCodeStateHasTokensAfterCompletion,
+				"Can't finish state in place '%s', place '%s' has token",
+				toPlaces[0],
+				place,
+			)
+		}
+
+		if _, ok := s.(CancellableStateInterface); !ok {
+			return state.NewError(
+				state.ErrCodeNetStateDoesntSupportCancellation,
+				"State doesn't support cancellation of tokens on completion",
+			)
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// clearOnCompletion cancels tokens in places except the terminal one if the policy is lenient.
+// The state is cancellable, it is checked by checkCompletion.
+func (n *Net) clearOnCompletion(ctx context.Context, s StateInterface, toPlaces []string) error {
+	if n.completionPolicy != CompletionPolicyLenient || !n.isTerminal(toPlaces) {
+		return nil
+	}
+
+	rest := make([]string, 0)
+
+	for _, place := range s.GetPlaces() {
+		if place != toPlaces[0] {
+			rest = append(rest, place)
+		}
+	}
+
+	if len(rest) == 0 {
+		return nil
+	}
+
+	return n.cancelTokens(ctx, s, rest)
+}
+
+func (n *Net) isTerminal(toPlaces []string) bool {
+	if len(toPlaces) != 1 {
+		return false
+	}
+
+	_, ok := cfg.GetTerminalPlaces(n.cfg)[toPlaces[0]]
+
+	return ok
+}
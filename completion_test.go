@@ -0,0 +1,103 @@
+package gowfnet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/state"
+)
+
+func newParallelNet(t *testing.T, policy CompletionPolicy) (*Net, *state.State) {
+	net := NewNet(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "a", "b", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"split":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"a", "b"}},
+			"finish": {From: []cfg.StringID{"a"}, To: []cfg.StringID{"finish"}},
+		},
+	})
+	net.WithCompletionPolicy(policy)
+
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+	require.NoError(t, net.Transit(context.Background(), st, "split"))
+
+	return net, st
+}
+
+func TestNet_Transit_CompletionPolicyLegacy_TokensAreKept(t *testing.T) {
+	net, st := newParallelNet(t, CompletionPolicyLegacy)
+
+	require.NoError(t, net.Transit(context.Background(), st, "finish"))
+	assert.True(t, st.IsFinished())
+	assert.ElementsMatch(t, []string{"b", "finish"}, st.GetPlaces())
+}
+
+func TestNet_Transit_CompletionPolicyStrict_ExpectedErr(t *testing.T) {
+	net, st := newParallelNet(t, CompletionPolicyStrict)
+
+	err := net.Transit(context.Background(), st, "finish")
+	assert.Equal(
+		t,
+		state.NewError(state.ErrCodeStateHasTokensAfterCompletion, "Can't finish state in place 'finish', place 'b' has token"),
+		err,
+	)
+	assert.False(t, st.IsFinished())
+	assert.ElementsMatch(t, []string{"a", "b"}, st.GetPlaces())
+}
+
+func TestNet_Start_CompletionPolicyStrict_NoErr(t *testing.T) {
+	net := NewNet(cfg.Minimal{
+		Start:       "start",
+		Finish:      "start",
+		Places:      []cfg.StringID{"start"},
+		Transitions: cfg.MinimalTransitionRegistry{},
+	})
+	net.WithCompletionPolicy(CompletionPolicyStrict)
+
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+	assert.True(t, st.IsFinished())
+}
+
+func TestNet_Transit_CompletionPolicyLenient_TokensAreCancelled(t *testing.T) {
+	net, st := newParallelNet(t, CompletionPolicyLenient)
+
+	require.NoError(t, net.Transit(context.Background(), st, "finish"))
+	assert.True(t, st.IsFinished())
+	assert.Equal(t, []string{"finish"}, st.GetPlaces())
+}
+
+func TestNet_Transit_CompletionPolicyLenientNotCancellableState_ExpectedErr(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	net := NewNet(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"finish": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}},
+		},
+	})
+	net.WithCompletionPolicy(CompletionPolicyLenient)
+
+	st := NewMockStateInterface(ctrl)
+	st.EXPECT().IsStarted().Return(true)
+	st.EXPECT().GetPlaces().Return([]string{"start", "b"})
+
+	err := net.Transit(context.Background(), st, "finish")
+	assert.Equal(
+		t,
+		state.NewError(
+			state.ErrCodeNetStateDoesntSupportCancellation,
+			"State doesn't support cancellation of tokens on completion",
+		),
+		err,
+	)
+}
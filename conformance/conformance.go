@@ -0,0 +1,226 @@
+package conformance
+
+import (
+	"sort"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/eventlog"
+)
+
+// DeviationKind is a kind of the difference between a trace and the net.
+type DeviationKind string
+
+const (
+	// DeviationUnknownTransition means the net doesn't have the transition of the event, the event is skipped.
+	DeviationUnknownTransition DeviationKind = "unknownTransition"
+	// DeviationMissingTokens means the transition isn't enabled, missing tokens are added for replay.
+	DeviationMissingTokens DeviationKind = "missingTokens"
+	// DeviationNotFinished means no terminal place has a token after the trace.
+	DeviationNotFinished DeviationKind = "notFinished"
+	// DeviationRemainingTokens means tokens are left in places after the trace.
+	DeviationRemainingTokens DeviationKind = "remainingTokens"
+)
+
+// Deviation is the first difference between a trace and the net.
+// Index is the index of the event in the trace, it equals the length of the trace for deviations on completion.
+type Deviation struct {
+	Kind         DeviationKind `json:"kind"`
+	Index        int           `json:"index"`
+	TransitionID string        `json:"transitionId,omitempty"`
+	Places       []string      `json:"places,omitempty"`
+}
+
+// Counters of token replay.
+type Counters struct {
+	Missing   int `json:"missing"`
+	Remaining int `json:"remaining"`
+	Consumed  int `json:"consumed"`
+	Produced  int `json:"produced"`
+}
+
+// GetFitness returns 1/2 * (1 - missing/consumed) + 1/2 * (1 - remaining/produced).
+// Fitness of a trace which fits the net is 1.
+func (c Counters) GetFitness() float64 {
+	res := 1.0
+
+	if c.Consumed > 0 {
+		res -= float64(c.Missing) / float64(c.Consumed) / 2
+	}
+
+	if c.Produced > 0 {
+		res -= float64(c.Remaining) / float64(c.Produced) / 2
+	}
+
+	return res
+}
+
+func (c *Counters) add(other Counters) {
+	c.Missing += other.Missing
+	c.Remaining += other.Remaining
+	c.Consumed += other.Consumed
+	c.Produced += other.Produced
+}
+
+// TraceResult is the result of replay of a trace.
+type TraceResult struct {
+	CaseID         string     `json:"caseId"`
+	Counters       Counters   `json:"counters"`
+	Fitness        float64    `json:"fitness"`
+	FirstDeviation *Deviation `json:"firstDeviation,omitempty"`
+}
+
+// Result is the result of replay of a log.
+// Fitness of the log is computed by the sum of counters of all traces.
+type Result struct {
+	Traces   []TraceResult `json:"traces"`
+	Counters Counters      `json:"counters"`
+	Fitness  float64       `json:"fitness"`
+}
+
+// Checker is a pure structural token replay of traces of transition ids on the config.
+//
+// The marking of replay counts tokens in places of the config, a net and a state are not involved,
+// so subprocesses run by transitions, error places, cancellation regions and the completion policy are ignored.
+// Transitions of subprocesses are unknown for the config of the parent.
+type Checker struct {
+	config    cfg.Interface
+	terminals []string
+}
+
+// New init checker for the config, use Net.GetCfg for the config of a net.
+func New(config cfg.Interface) *Checker {
+	terminals := make([]string, 0)
+	for place := range cfg.GetTerminalPlaces(config) {
+		terminals = append(terminals, place)
+	}
+
+	sort.Strings(terminals)
+
+	return &Checker{config: config, terminals: terminals}
+}
+
+// Check replays all traces of the log, activities of events are ids of transitions.
+func (c *Checker) Check(log *eventlog.Log) Result {
+	res := Result{Traces: make([]TraceResult, 0, len(log.Traces))}
+
+	for _, trace := range log.Traces {
+		traceRes := c.Replay(trace.CaseID, trace.GetActivities())
+		res.Traces = append(res.Traces, traceRes)
+		res.Counters.add(traceRes.Counters)
+	}
+
+	res.Fitness = res.Counters.GetFitness()
+
+	return res
+}
+
+// Replay the trace of transition ids of the case.
+//
+// A token is produced in the start place before the trace and a token is consumed from a terminal place after it.
+// Missing tokens are created for transitions which are not enabled, tokens left after the trace are remaining.
+func (c *Checker) Replay(caseID string, transitionIDs []string) TraceResult {
+	r := &replay{marking: map[string]int{c.config.GetStart().GetID(): 1}}
+	r.counters.Produced++
+
+	transitions := c.config.GetTransitions().GetAsMap()
+
+	for i, id := range transitionIDs {
+		transition, ok := transitions[id]
+		if !ok {
+			r.deviate(Deviation{Kind: DeviationUnknownTransition, Index: i, TransitionID: id})
+
+			continue
+		}
+
+		if missing := r.consume(transition.GetFrom()); len(missing) > 0 {
+			r.deviate(Deviation{Kind: DeviationMissingTokens, Index: i, TransitionID: id, Places: missing})
+		}
+
+		r.produce(transition.GetTo())
+	}
+
+	c.complete(r, len(transitionIDs))
+
+	return TraceResult{
+		CaseID:         caseID,
+		Counters:       r.counters,
+		Fitness:        r.counters.GetFitness(),
+		FirstDeviation: r.firstDeviation,
+	}
+}
+
+// complete consumes a token from the first marked terminal place and counts remaining tokens.
+func (c *Checker) complete(r *replay, index int) {
+	r.counters.Consumed++
+
+	finished := false
+
+	for _, place := range c.terminals {
+		if r.marking[place] > 0 {
+			r.marking[place]--
+			finished = true
+
+			break
+		}
+	}
+
+	if !finished {
+		r.counters.Missing++
+		r.deviate(Deviation{Kind: DeviationNotFinished, Index: index, Places: c.terminals})
+	}
+
+	remaining := make([]string, 0)
+
+	for place, count := range r.marking {
+		if count > 0 {
+			r.counters.Remaining += count
+			remaining = append(remaining, place)
+		}
+	}
+
+	if len(remaining) > 0 {
+		sort.Strings(remaining)
+		r.deviate(Deviation{Kind: DeviationRemainingTokens, Index: index, Places: remaining})
+	}
+}
+
+type replay struct {
+	marking        map[string]int
+	counters       Counters
+	firstDeviation *Deviation
+}
+
+// consume tokens from places, returns sorted places where tokens were missing.
+func (r *replay) consume(places []cfg.IDGetter) []string {
+	missing := make([]string, 0)
+
+	for _, place := range places {
+		r.counters.Consumed++
+
+		if r.marking[place.GetID()] == 0 {
+			r.counters.Missing++
+			missing = append(missing, place.GetID())
+
+			continue
+		}
+
+		r.marking[place.GetID()]--
+	}
+
+	sort.Strings(missing)
+
+	return missing
+}
+
+func (r *replay) produce(places []cfg.IDGetter) {
+	for _, place := range places {
+		r.counters.Produced++
+		r.marking[place.GetID()]++
+	}
+}
+
+func (r *replay) deviate(deviation Deviation) {
+	if r.firstDeviation == nil {
+		r.firstDeviation = &deviation
+	}
+}
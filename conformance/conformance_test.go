@@ -0,0 +1,135 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/eventlog"
+)
+
+func newTestChecker() *Checker {
+	return New(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "review", "changes", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"submit":         {From: []cfg.StringID{"start"}, To: []cfg.StringID{"review"}},
+			"requestChanges": {From: []cfg.StringID{"review"}, To: []cfg.StringID{"changes"}},
+			"resubmit":       {From: []cfg.StringID{"changes"}, To: []cfg.StringID{"review"}},
+			"approve":        {From: []cfg.StringID{"review"}, To: []cfg.StringID{"finish"}},
+		},
+	})
+}
+
+func TestChecker_Replay_ExpectedResult(t *testing.T) {
+	type data struct {
+		trace    []string
+		expected TraceResult
+	}
+
+	dp := map[string]data{
+		"fitting trace": {
+			trace: []string{"submit", "requestChanges", "resubmit", "approve"},
+			expected: TraceResult{
+				CaseID:   "1",
+				Counters: Counters{Consumed: 5, Produced: 5},
+				Fitness:  1,
+			},
+		},
+		"skipped transition": {
+			trace: []string{"approve"},
+			expected: TraceResult{
+				CaseID:   "1",
+				Counters: Counters{Missing: 1, Remaining: 1, Consumed: 2, Produced: 2},
+				Fitness:  0.5,
+				FirstDeviation: &Deviation{
+					Kind:         DeviationMissingTokens,
+					Index:        0,
+					TransitionID: "approve",
+					Places:       []string{"review"},
+				},
+			},
+		},
+		"unknown transition": {
+			trace: []string{"submit", "comment", "approve"},
+			expected: TraceResult{
+				CaseID:         "1",
+				Counters:       Counters{Consumed: 3, Produced: 3},
+				Fitness:        1,
+				FirstDeviation: &Deviation{Kind: DeviationUnknownTransition, Index: 1, TransitionID: "comment"},
+			},
+		},
+		"not finished": {
+			trace: []string{"submit"},
+			expected: TraceResult{
+				CaseID:         "1",
+				Counters:       Counters{Missing: 1, Remaining: 1, Consumed: 2, Produced: 2},
+				Fitness:        0.5,
+				FirstDeviation: &Deviation{Kind: DeviationNotFinished, Index: 1, Places: []string{"finish"}},
+			},
+		},
+		"missing token after loop": {
+			trace: []string{"submit", "requestChanges", "approve"},
+			expected: TraceResult{
+				CaseID:   "1",
+				Counters: Counters{Missing: 1, Remaining: 1, Consumed: 4, Produced: 4},
+				Fitness:  0.75,
+				FirstDeviation: &Deviation{
+					Kind:         DeviationMissingTokens,
+					Index:        2,
+					TransitionID: "approve",
+					Places:       []string{"review"},
+				},
+			},
+		},
+	}
+
+	for name, d := range dp {
+		d := d
+
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, d.expected, newTestChecker().Replay("1", d.trace))
+		})
+	}
+}
+
+func TestChecker_Replay_TokenIsLeft_RemainingTokensDeviation(t *testing.T) {
+	checker := New(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "a", "b", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"split": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"a", "b"}},
+			"done":  {From: []cfg.StringID{"a"}, To: []cfg.StringID{"finish"}},
+		},
+	})
+
+	assert.Equal(
+		t,
+		TraceResult{
+			CaseID:         "1",
+			Counters:       Counters{Remaining: 1, Consumed: 3, Produced: 4},
+			Fitness:        0.875,
+			FirstDeviation: &Deviation{Kind: DeviationRemainingTokens, Index: 2, Places: []string{"b"}},
+		},
+		checker.Replay("1", []string{"split", "done"}),
+	)
+}
+
+func TestChecker_Check_ExpectedResult(t *testing.T) {
+	res := newTestChecker().Check(eventlog.NewLog(
+		eventlog.NewTrace("1", "submit", "approve"),
+		eventlog.NewTrace("2", "approve"),
+	))
+
+	assert.Len(t, res.Traces, 2)
+	assert.Equal(t, "2", res.Traces[1].CaseID)
+	assert.Equal(t, Counters{Missing: 1, Remaining: 1, Consumed: 5, Produced: 5}, res.Counters)
+	assert.InDelta(t, 0.8, res.Fitness, 0.0001)
+}
+
+func TestCounters_GetFitness_Empty(t *testing.T) {
+	assert.Equal(t, 1.0, Counters{}.GetFitness())
+}
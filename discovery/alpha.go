@@ -0,0 +1,282 @@
+package discovery
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/eventlog"
+	"github.com/andrskom/gowfnet/state"
+)
+
+const ErrCodeLogIsEmpty state.ErrCode = "gowfnet.discovery.logIsEmpty"
+
+// Ids of the start and the finish places of discovered config.
+const (
+	StartPlace  = "start"
+	FinishPlace = "finish"
+)
+
+// Relation of two activities in footprint of log.
+type Relation int
+
+const (
+	// RelationChoice means activities never directly follow each other.
+	RelationChoice Relation = iota
+	// RelationCausal means the first activity is directly followed by the second one, but not vice versa.
+	RelationCausal
+	// RelationReverseCausal means the second activity is directly followed by the first one, but not vice versa.
+	RelationReverseCausal
+	// RelationParallel means activities directly follow each other in both orders.
+	RelationParallel
+)
+
+// Footprint is the matrix of relations of activities of log.
+type Footprint struct {
+	activities []string
+	follows    map[string]map[string]struct{}
+	starts     map[string]struct{}
+	ends       map[string]struct{}
+}
+
+// NewFootprint builds footprint of log.
+func NewFootprint(log *eventlog.Log) *Footprint {
+	res := &Footprint{
+		activities: log.GetActivities(),
+		follows:    make(map[string]map[string]struct{}),
+		starts:     make(map[string]struct{}),
+		ends:       make(map[string]struct{}),
+	}
+
+	for _, trace := range log.Traces {
+		activities := trace.GetActivities()
+		if len(activities) == 0 {
+			continue
+		}
+
+		res.starts[activities[0]] = struct{}{}
+		res.ends[activities[len(activities)-1]] = struct{}{}
+
+		for i := 0; i+1 < len(activities); i++ {
+			if _, ok := res.follows[activities[i]]; !ok {
+				res.follows[activities[i]] = make(map[string]struct{})
+			}
+
+			res.follows[activities[i]][activities[i+1]] = struct{}{}
+		}
+	}
+
+	return res
+}
+
+// GetActivities returns sorted activities of log.
+func (f *Footprint) GetActivities() []string {
+	return f.activities
+}
+
+// Get relation of activities.
+func (f *Footprint) Get(a string, b string) Relation {
+	ab := f.isFollowed(a, b)
+	ba := f.isFollowed(b, a)
+
+	switch {
+	case ab && ba:
+		return RelationParallel
+	case ab:
+		return RelationCausal
+	case ba:
+		return RelationReverseCausal
+	default:
+		return RelationChoice
+	}
+}
+
+func (f *Footprint) isFollowed(a string, b string) bool {
+	_, ok := f.follows[a][b]
+
+	return ok
+}
+
+// Alpha discovers config from log by the alpha algorithm.
+//
+// Each activity becomes a transition with the same id. Places between transitions are named
+// by sets of activities, e.g. "p({a},{b,c})". The start place is the input of the first activities of traces
+// and the finish place is the output of the last ones.
+// The alpha algorithm doesn't discover loops of one or two activities and it is exponential
+// in the number of activities in the worst case.
+func Alpha(log *eventlog.Log) (cfg.Minimal, error) {
+	footprint := NewFootprint(log)
+	if len(footprint.GetActivities()) == 0 {
+		return cfg.Minimal{}, state.NewError(ErrCodeLogIsEmpty, "Log doesn't have events")
+	}
+
+	res := cfg.Minimal{
+		Start:       StartPlace,
+		Finish:      FinishPlace,
+		Places:      []cfg.StringID{StartPlace},
+		Transitions: make(cfg.MinimalTransitionRegistry),
+	}
+
+	for _, activity := range footprint.GetActivities() {
+		transition := cfg.MinimalTransition{From: make([]cfg.StringID, 0), To: make([]cfg.StringID, 0)}
+
+		if _, ok := footprint.starts[activity]; ok {
+			transition.From = append(transition.From, StartPlace)
+		}
+
+		res.Transitions[activity] = transition
+	}
+
+	for _, p := range buildAlphaPairs(footprint) {
+		id := cfg.StringID(p.String())
+		res.Places = append(res.Places, id)
+
+		for _, activity := range p.from {
+			transition := res.Transitions[activity]
+			transition.To = append(transition.To, id)
+			res.Transitions[activity] = transition
+		}
+
+		for _, activity := range p.to {
+			transition := res.Transitions[activity]
+			transition.From = append(transition.From, id)
+			res.Transitions[activity] = transition
+		}
+	}
+
+	res.Places = append(res.Places, FinishPlace)
+
+	for activity := range footprint.ends {
+		transition := res.Transitions[activity]
+		transition.To = append(transition.To, FinishPlace)
+		res.Transitions[activity] = transition
+	}
+
+	return res, nil
+}
+
+// pair is a place of alpha algorithm, each activity of from is causal for each activity of to.
+type pair struct {
+	from []string
+	to   []string
+}
+
+func (p pair) String() string {
+	return "p({" + strings.Join(p.from, ",") + "},{" + strings.Join(p.to, ",") + "})"
+}
+
+// buildAlphaPairs returns maximal pairs of sets of activities, pairs are sorted by names.
+func buildAlphaPairs(f *Footprint) []pair {
+	pairs := make([]pair, 0)
+
+	for _, a := range f.GetActivities() {
+		for _, b := range f.GetActivities() {
+			if f.Get(a, b) == RelationCausal {
+				pairs = append(pairs, pair{from: []string{a}, to: []string{b}})
+			}
+		}
+	}
+
+	seen := make(map[string]struct{}, len(pairs))
+	for _, p := range pairs {
+		seen[p.String()] = struct{}{}
+	}
+
+	for i := 0; i < len(pairs); i++ {
+		for j := i + 1; j < len(pairs); j++ {
+			if !isSubset(pairs[i].from, pairs[j].from) && !isSubset(pairs[i].to, pairs[j].to) &&
+				!isSubset(pairs[j].from, pairs[i].from) && !isSubset(pairs[j].to, pairs[i].to) {
+				continue
+			}
+
+			merged := pair{from: union(pairs[i].from, pairs[j].from), to: union(pairs[i].to, pairs[j].to)}
+			if _, ok := seen[merged.String()]; ok || !isAlphaPair(f, merged) {
+				continue
+			}
+
+			seen[merged.String()] = struct{}{}
+			pairs = append(pairs, merged)
+		}
+	}
+
+	res := make([]pair, 0)
+
+	for i := range pairs {
+		maximal := true
+
+		for j := range pairs {
+			if i != j && isSubset(pairs[i].from, pairs[j].from) && isSubset(pairs[i].to, pairs[j].to) {
+				maximal = false
+
+				break
+			}
+		}
+
+		if maximal {
+			res = append(res, pairs[i])
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].String() < res[j].String()
+	})
+
+	return res
+}
+
+func isAlphaPair(f *Footprint, p pair) bool {
+	for _, a := range p.from {
+		for _, b := range p.to {
+			if f.Get(a, b) != RelationCausal {
+				return false
+			}
+		}
+	}
+
+	return isChoiceSet(f, p.from) && isChoiceSet(f, p.to)
+}
+
+func isChoiceSet(f *Footprint, activities []string) bool {
+	for _, a := range activities {
+		for _, b := range activities {
+			if f.Get(a, b) != RelationChoice {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// isSubset returns true if sorted a is a subset of sorted b.
+func isSubset(a []string, b []string) bool {
+	j := 0
+
+	for _, item := range a {
+		for j < len(b) && b[j] < item {
+			j++
+		}
+
+		if j == len(b) || b[j] != item {
+			return false
+		}
+	}
+
+	return true
+}
+
+func union(a []string, b []string) []string {
+	set := make(map[string]struct{}, len(a)+len(b))
+	for _, item := range append(append([]string{}, a...), b...) {
+		set[item] = struct{}{}
+	}
+
+	res := make([]string, 0, len(set))
+	for item := range set {
+		res = append(res, item)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
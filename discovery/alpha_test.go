@@ -0,0 +1,106 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/cfg/validator"
+	"github.com/andrskom/gowfnet/eventlog"
+	"github.com/andrskom/gowfnet/state"
+)
+
+func newTestLog() *eventlog.Log {
+	return eventlog.NewLog(
+		eventlog.NewTrace("1", "a", "b", "c", "d"),
+		eventlog.NewTrace("2", "a", "c", "b", "d"),
+		eventlog.NewTrace("3", "a", "e", "d"),
+	)
+}
+
+func TestFootprint_Get(t *testing.T) {
+	f := NewFootprint(newTestLog())
+
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, f.GetActivities())
+	assert.Equal(t, RelationCausal, f.Get("a", "b"))
+	assert.Equal(t, RelationReverseCausal, f.Get("b", "a"))
+	assert.Equal(t, RelationParallel, f.Get("b", "c"))
+	assert.Equal(t, RelationChoice, f.Get("b", "e"))
+	assert.Equal(t, RelationChoice, f.Get("a", "a"))
+}
+
+func TestAlpha_ExpectedCfg(t *testing.T) {
+	res, err := Alpha(newTestLog())
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		cfg.Minimal{
+			Start:  "start",
+			Finish: "finish",
+			Places: []cfg.StringID{
+				"start",
+				"p({a},{b,e})",
+				"p({a},{c,e})",
+				"p({b,e},{d})",
+				"p({c,e},{d})",
+				"finish",
+			},
+			Transitions: cfg.MinimalTransitionRegistry{
+				"a": {
+					From: []cfg.StringID{"start"},
+					To:   []cfg.StringID{"p({a},{b,e})", "p({a},{c,e})"},
+				},
+				"b": {
+					From: []cfg.StringID{"p({a},{b,e})"},
+					To:   []cfg.StringID{"p({b,e},{d})"},
+				},
+				"c": {
+					From: []cfg.StringID{"p({a},{c,e})"},
+					To:   []cfg.StringID{"p({c,e},{d})"},
+				},
+				"d": {
+					From: []cfg.StringID{"p({b,e},{d})", "p({c,e},{d})"},
+					To:   []cfg.StringID{"finish"},
+				},
+				"e": {
+					From: []cfg.StringID{"p({a},{b,e})", "p({a},{c,e})"},
+					To:   []cfg.StringID{"p({b,e},{d})", "p({c,e},{d})"},
+				},
+			},
+		},
+		res,
+	)
+	assert.NoError(t, validator.NewCombinedWithAllValidators().Validate(res))
+}
+
+func TestAlpha_Choice_ExpectedCfg(t *testing.T) {
+	res, err := Alpha(eventlog.NewLog(
+		eventlog.NewTrace("1", "register", "approve"),
+		eventlog.NewTrace("2", "register", "reject"),
+	))
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		cfg.Minimal{
+			Start:  "start",
+			Finish: "finish",
+			Places: []cfg.StringID{"start", "p({register},{approve,reject})", "finish"},
+			Transitions: cfg.MinimalTransitionRegistry{
+				"register": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"p({register},{approve,reject})"}},
+				"approve":  {From: []cfg.StringID{"p({register},{approve,reject})"}, To: []cfg.StringID{"finish"}},
+				"reject":   {From: []cfg.StringID{"p({register},{approve,reject})"}, To: []cfg.StringID{"finish"}},
+			},
+		},
+		res,
+	)
+}
+
+func TestAlpha_EmptyLog_ExpectedErr(t *testing.T) {
+	_, err := Alpha(eventlog.NewLog(eventlog.NewTrace("1")))
+
+	assert.Equal(t, state.NewError(ErrCodeLogIsEmpty, "Log doesn't have events"), err)
+}
@@ -0,0 +1,52 @@
+package gowfnet
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/state"
+)
+
+// engineErrCodePrefix is a prefix of codes of errors of the lib, such errors are not failures of listeners.
+const engineErrCodePrefix = "gowfnet."
+
+// routeToErrorPlace moves tokens from input places of the transition to its error place,
+// if the transition has it and the cause is a failure of a listener.
+//
+// Listeners and the state get ctx with the cause, see state.GetErrorCause.
+// If tokens are moved, err with ErrCodeNetTransitionIsRoutedToErrorPlace code is returned, otherwise the cause.
+func (n *Net) routeToErrorPlace(ctx context.Context, s StateInterface, transitionID string, cause error) error {
+	transition := n.transitionMap[transitionID]
+
+	errPlace := cfg.GetErrorPlace(transition)
+	if errPlace == nil || isEngineError(cause) {
+		return cause
+	}
+
+	err := n.process(
+		state.WithErrorCause(ctx, cause),
+		s,
+		buildStringSliceFromIDGetter(transition.GetFrom()...),
+		[]string{errPlace.GetID()},
+		nil,
+	)
+	if err != nil {
+		return cause
+	}
+
+	return state.Wrapf(
+		state.ErrCodeNetTransitionIsRoutedToErrorPlace,
+		cause,
+		"Transition '%s' is failed, tokens are moved to error place '%s'",
+		transitionID,
+		errPlace.GetID(),
+	)
+}
+
+func isEngineError(err error) bool {
+	var stateErr *state.Error
+
+	return errors.As(err, &stateErr) && strings.HasPrefix(string(stateErr.GetCode()), engineErrCodePrefix)
+}
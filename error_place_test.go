@@ -0,0 +1,126 @@
+package gowfnet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/state"
+)
+
+type testingFailingListener struct {
+	*StubListener
+	transitionErr error
+	stateListener state.ListenerInterface
+}
+
+func (l *testingFailingListener) BeforeTransition(ctx context.Context, transitionID string, s StateOpInterface) error {
+	return l.transitionErr
+}
+
+func (l *testingFailingListener) HasStateListener() bool {
+	return l.stateListener != nil
+}
+
+func (l *testingFailingListener) GetStateListener() state.ListenerInterface {
+	return l.stateListener
+}
+
+type testingFailingStateListener struct {
+	*state.StubListener
+	causes []error
+}
+
+func (l *testingFailingStateListener) BeforeMove(ctx context.Context, st state.OpInterface, from []string, to []string) error {
+	if cause, ok := state.GetErrorCause(ctx); ok {
+		l.causes = append(l.causes, cause)
+
+		return nil
+	}
+
+	if _, ok := state.GetTransitionID(ctx); !ok {
+		return nil
+	}
+
+	return errors.New("move failed")
+}
+
+func newErrorPlaceNet(t *testing.T, listener ListenerInterface) (*Net, *state.State) {
+	net := NewNet(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "failed", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"send":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}, ErrorPlace: "failed"},
+			"retry": {From: []cfg.StringID{"failed"}, To: []cfg.StringID{"start"}},
+		},
+	})
+
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+
+	net.WithListener(listener)
+
+	return net, st
+}
+
+func TestNet_Transit_BeforeTransitionErr_TokensAreMovedToErrorPlace(t *testing.T) {
+	cause := errors.New("a")
+	net, st := newErrorPlaceNet(t, &testingFailingListener{
+		StubListener:  NewStubListener(),
+		transitionErr: cause,
+	})
+
+	err := net.Transit(context.Background(), st, "send")
+	assert.Equal(
+		t,
+		state.Wrap(state.ErrCodeNetTransitionIsRoutedToErrorPlace, cause, "Transition 'send' is failed, tokens are moved to error place 'failed'"),
+		err,
+	)
+	assert.True(t, errors.Is(err, cause))
+	assert.True(t, errors.Is(err, state.ErrNetTransitionIsRoutedToErrorPlace))
+	assert.Equal(t, []string{"failed"}, st.GetPlaces())
+	assert.False(t, st.IsError())
+}
+
+func TestNet_Transit_BeforeMoveErr_TokensAreMovedToErrorPlace(t *testing.T) {
+	stateListener := &testingFailingStateListener{StubListener: state.NewStubListener()}
+	net, st := newErrorPlaceNet(t, &testingFailingListener{
+		StubListener:  NewStubListener(),
+		stateListener: stateListener,
+	})
+
+	err := net.Transit(context.Background(), st, "send")
+	assert.True(t, state.ErrorIs(state.ErrCodeNetTransitionIsRoutedToErrorPlace, err))
+	assert.Equal(t, []string{"failed"}, st.GetPlaces())
+	assert.Equal(t, []error{errors.New("move failed")}, stateListener.causes)
+}
+
+func TestNet_Transit_EngineErr_TokensAreNotMoved(t *testing.T) {
+	eErr := state.NewError(state.ErrCodeStateHasNotTokenInPlace, "a")
+	net, st := newErrorPlaceNet(t, &testingFailingListener{
+		StubListener:  NewStubListener(),
+		transitionErr: eErr,
+	})
+
+	assert.Same(t, eErr, net.Transit(context.Background(), st, "send"))
+	assert.Equal(t, []string{"start"}, st.GetPlaces())
+}
+
+func TestNet_Transit_WithoutErrorPlace_TheSameErr(t *testing.T) {
+	eErr := errors.New("a")
+	net, st := newErrorPlaceNet(t, &testingFailingListener{
+		StubListener:  NewStubListener(),
+		transitionErr: eErr,
+	})
+
+	require.Error(t, net.Transit(context.Background(), st, "send"))
+	require.Equal(t, []string{"failed"}, st.GetPlaces())
+
+	assert.Same(t, eErr, net.Transit(context.Background(), st, "retry"))
+	assert.Equal(t, []string{"failed"}, st.GetPlaces())
+}
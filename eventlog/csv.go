@@ -0,0 +1,112 @@
+package eventlog
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/andrskom/gowfnet/state"
+)
+
+const (
+	ErrCodeCSVColumnIsNotFound state.ErrCode = "gowfnet.eventlog.csvColumnIsNotFound"
+	ErrCodeCSVBadTimestamp     state.ErrCode = "gowfnet.eventlog.csvBadTimestamp"
+)
+
+// Columns of csv log.
+const (
+	ColumnCase      = "case"
+	ColumnActivity  = "activity"
+	ColumnTimestamp = "timestamp"
+)
+
+// ReadCSV reads log from csv with header.
+//
+// Columns "case" and "activity" are required. Column "timestamp" is optional, it is in RFC 3339 format,
+// events of a case are sorted by it, otherwise they are in order of rows.
+// Other columns are attributes of events. Traces are in order of first rows of cases.
+func ReadCSV(r io.Reader) (*Log, error) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[name] = i
+	}
+
+	for _, name := range []string{ColumnCase, ColumnActivity} {
+		if _, ok := columns[name]; !ok {
+			return nil, state.NewErrorf(ErrCodeCSVColumnIsNotFound, "Column '%s' is not found in csv header", name)
+		}
+	}
+
+	res := NewLog()
+	traces := make(map[string]int)
+
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		event, err := buildCSVEvent(header, columns, row, line)
+		if err != nil {
+			return nil, err
+		}
+
+		caseID := row[columns[ColumnCase]]
+
+		i, ok := traces[caseID]
+		if !ok {
+			i = len(res.Traces)
+			traces[caseID] = i
+			res.Add(Trace{CaseID: caseID, Events: make([]Event, 0)})
+		}
+
+		res.Traces[i].Events = append(res.Traces[i].Events, event)
+	}
+
+	if _, ok := columns[ColumnTimestamp]; ok {
+		for _, trace := range res.Traces {
+			events := trace.Events
+			sort.SliceStable(events, func(i, j int) bool {
+				return events[i].Timestamp.Before(events[j].Timestamp)
+			})
+		}
+	}
+
+	return res, nil
+}
+
+func buildCSVEvent(header []string, columns map[string]int, row []string, line int) (Event, error) {
+	res := Event{
+		Activity:   row[columns[ColumnActivity]],
+		Attributes: make(map[string]string),
+	}
+
+	for i, name := range header {
+		switch name {
+		case ColumnCase, ColumnActivity:
+		case ColumnTimestamp:
+			timestamp, err := time.Parse(time.RFC3339, row[i])
+			if err != nil {
+				return Event{}, state.Wrapf(ErrCodeCSVBadTimestamp, err, "Bad timestamp in line %d", line)
+			}
+
+			res.Timestamp = timestamp
+		default:
+			res.Attributes[name] = row[i]
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,80 @@
+package eventlog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet/state"
+)
+
+func TestReadCSV_ExpectedLog(t *testing.T) {
+	log, err := ReadCSV(strings.NewReader(
+		"case,activity,timestamp,user\n" +
+			"1,register,2020-01-01T10:00:00Z,bob\n" +
+			"2,register,2020-01-01T11:00:00Z,alice\n" +
+			"1,approve,2020-01-01T09:00:00Z,bob\n",
+	))
+
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		&Log{Traces: []Trace{
+			{
+				CaseID: "1",
+				Events: []Event{
+					{
+						Activity:   "approve",
+						Timestamp:  time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC),
+						Attributes: map[string]string{"user": "bob"},
+					},
+					{
+						Activity:   "register",
+						Timestamp:  time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+						Attributes: map[string]string{"user": "bob"},
+					},
+				},
+			},
+			{
+				CaseID: "2",
+				Events: []Event{
+					{
+						Activity:   "register",
+						Timestamp:  time.Date(2020, 1, 1, 11, 0, 0, 0, time.UTC),
+						Attributes: map[string]string{"user": "alice"},
+					},
+				},
+			},
+		}},
+		log,
+	)
+}
+
+func TestReadCSV_WithoutTimestamp_OrderOfRows(t *testing.T) {
+	log, err := ReadCSV(strings.NewReader("activity,case\nb,1\na,1\n"))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a"}, log.Traces[0].GetActivities())
+}
+
+func TestReadCSV_WithoutColumn_ExpectedErr(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader("case\n1\n"))
+
+	assert.Equal(t, state.NewError(ErrCodeCSVColumnIsNotFound, "Column 'activity' is not found in csv header"), err)
+}
+
+func TestReadCSV_BadTimestamp_ExpectedErr(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader("case,activity,timestamp\n1,a,yesterday\n"))
+
+	assert.True(t, state.ErrorIs(ErrCodeCSVBadTimestamp, err))
+	assert.Contains(t, err.Error(), "Bad timestamp in line 2")
+}
+
+func TestReadCSV_EmptyInput_ExpectedErr(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader(""))
+
+	assert.Error(t, err)
+}
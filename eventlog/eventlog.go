@@ -0,0 +1,78 @@
+package eventlog
+
+import (
+	"sort"
+	"time"
+)
+
+// Event is an execution of the activity in a case, e.g. a fired transition.
+type Event struct {
+	Activity   string
+	Timestamp  time.Time
+	Attributes map[string]string
+}
+
+// Trace is a sequence of events of one case ordered by execution.
+// Attributes are attributes of the case, e.g. of the subject of the state.
+type Trace struct {
+	CaseID     string
+	Attributes map[string]string
+	Events     []Event
+}
+
+// NewTrace init trace of the case with events of activities without timestamps.
+func NewTrace(caseID string, activities ...string) Trace {
+	res := Trace{CaseID: caseID, Events: make([]Event, 0, len(activities))}
+
+	for _, activity := range activities {
+		res.Events = append(res.Events, Event{Activity: activity})
+	}
+
+	return res
+}
+
+// GetActivities returns activities of events in order of execution.
+func (t Trace) GetActivities() []string {
+	res := make([]string, 0, len(t.Events))
+
+	for _, event := range t.Events {
+		res = append(res, event.Activity)
+	}
+
+	return res
+}
+
+// Log is a set of traces.
+type Log struct {
+	Traces []Trace
+}
+
+// NewLog init log with traces.
+func NewLog(traces ...Trace) *Log {
+	return &Log{Traces: append(make([]Trace, 0, len(traces)), traces...)}
+}
+
+// Add trace to the log.
+func (l *Log) Add(trace Trace) {
+	l.Traces = append(l.Traces, trace)
+}
+
+// GetActivities returns sorted unique activities of all traces.
+func (l *Log) GetActivities() []string {
+	set := make(map[string]struct{})
+
+	for _, trace := range l.Traces {
+		for _, event := range trace.Events {
+			set[event.Activity] = struct{}{}
+		}
+	}
+
+	res := make([]string, 0, len(set))
+	for activity := range set {
+		res = append(res, activity)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
@@ -0,0 +1,22 @@
+package eventlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTrace(t *testing.T) {
+	trace := NewTrace("1", "a", "b")
+
+	assert.Equal(t, Trace{CaseID: "1", Events: []Event{{Activity: "a"}, {Activity: "b"}}}, trace)
+	assert.Equal(t, []string{"a", "b"}, trace.GetActivities())
+}
+
+func TestLog_GetActivities(t *testing.T) {
+	log := NewLog(NewTrace("1", "b", "a"))
+	log.Add(NewTrace("2", "c", "a"))
+
+	assert.Equal(t, []string{"a", "b", "c"}, log.GetActivities())
+	assert.Len(t, log.Traces, 2)
+}
@@ -0,0 +1,18 @@
+package eventlog
+
+import (
+	"github.com/andrskom/gowfnet/state"
+)
+
+// NewTraceFromHistory builds trace of the case from history of the state, see state.State.GetHistory.
+// Each entry becomes an event with the transition id as activity and the time of the entry as timestamp.
+// Attributes are attributes of the case, e.g. fields of the subject of the state.
+func NewTraceFromHistory(caseID string, history []state.HistoryEntry, attributes map[string]string) Trace {
+	res := Trace{CaseID: caseID, Attributes: attributes, Events: make([]Event, 0, len(history))}
+
+	for _, entry := range history {
+		res.Events = append(res.Events, Event{Activity: entry.TransitionID, Timestamp: entry.At})
+	}
+
+	return res
+}
@@ -0,0 +1,187 @@
+package eventlog
+
+import (
+	"encoding/xml"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/andrskom/gowfnet/state"
+)
+
+const ErrCodeXESBadTimestamp state.ErrCode = "gowfnet.eventlog.xesBadTimestamp"
+
+// Keys of standard attributes of XES.
+const (
+	XESKeyName      = "concept:name"
+	XESKeyTimestamp = "time:timestamp"
+)
+
+const xesDateFormat = "2006-01-02T15:04:05.000Z07:00"
+
+type xesLog struct {
+	XMLName    xml.Name       `xml:"log"`
+	Version    string         `xml:"xes.version,attr"`
+	Features   string         `xml:"xes.features,attr,omitempty"`
+	Xmlns      string         `xml:"xmlns,attr"`
+	Extensions []xesExtension `xml:"extension"`
+	Traces     []xesTrace     `xml:"trace"`
+}
+
+type xesExtension struct {
+	Name   string `xml:"name,attr"`
+	Prefix string `xml:"prefix,attr"`
+	URI    string `xml:"uri,attr"`
+}
+
+type xesTrace struct {
+	Attributes []xesAttribute `xml:",any"`
+	Events     []xesEvent     `xml:"event"`
+}
+
+type xesEvent struct {
+	Attributes []xesAttribute `xml:",any"`
+}
+
+// xesAttribute is an attribute of any type, the name of the element is the type, e.g. "string" or "date".
+type xesAttribute struct {
+	XMLName xml.Name
+	Key     string `xml:"key,attr"`
+	Value   string `xml:"value,attr"`
+}
+
+// ReadXES reads log from XES (IEEE 1849) document.
+//
+// The concept:name attribute of a trace is the case id and of an event is the activity,
+// time:timestamp of an event is its timestamp. Other attributes of any type are kept as strings,
+// nested attributes, globals and classifiers are ignored.
+func ReadXES(r io.Reader) (*Log, error) {
+	var doc xesLog
+
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	res := NewLog()
+
+	for _, xTrace := range doc.Traces {
+		trace := Trace{Attributes: make(map[string]string), Events: make([]Event, 0, len(xTrace.Events))}
+
+		for _, attr := range xTrace.Attributes {
+			if attr.Key == XESKeyName {
+				trace.CaseID = attr.Value
+
+				continue
+			}
+
+			trace.Attributes[attr.Key] = attr.Value
+		}
+
+		for _, xEvent := range xTrace.Events {
+			event, err := buildXESEvent(xEvent)
+			if err != nil {
+				return nil, err
+			}
+
+			trace.Events = append(trace.Events, event)
+		}
+
+		res.Add(trace)
+	}
+
+	return res, nil
+}
+
+func buildXESEvent(xEvent xesEvent) (Event, error) {
+	res := Event{Attributes: make(map[string]string)}
+
+	for _, attr := range xEvent.Attributes {
+		switch attr.Key {
+		case XESKeyName:
+			res.Activity = attr.Value
+		case XESKeyTimestamp:
+			timestamp, err := time.Parse(time.RFC3339, attr.Value)
+			if err != nil {
+				return Event{}, state.Wrapf(ErrCodeXESBadTimestamp, err, "Bad timestamp of event '%s'", res.Activity)
+			}
+
+			res.Timestamp = timestamp
+		default:
+			res.Attributes[attr.Key] = attr.Value
+		}
+	}
+
+	return res, nil
+}
+
+// WriteXES writes log as XES document.
+// Attributes of traces and events are written as strings, events without timestamp are written without it.
+func WriteXES(w io.Writer, log *Log) error {
+	doc := xesLog{
+		Version: "1.0",
+		Xmlns:   "http://www.xes-standard.org/",
+		Extensions: []xesExtension{
+			{Name: "Concept", Prefix: "concept", URI: "http://www.xes-standard.org/concept.xesext"},
+			{Name: "Time", Prefix: "time", URI: "http://www.xes-standard.org/time.xesext"},
+		},
+		Traces: make([]xesTrace, 0, len(log.Traces)),
+	}
+
+	for _, trace := range log.Traces {
+		xTrace := xesTrace{
+			Attributes: append(
+				[]xesAttribute{newXESAttribute("string", XESKeyName, trace.CaseID)},
+				buildXESAttributes(trace.Attributes)...,
+			),
+			Events: make([]xesEvent, 0, len(trace.Events)),
+		}
+
+		for _, event := range trace.Events {
+			attrs := []xesAttribute{newXESAttribute("string", XESKeyName, event.Activity)}
+
+			if !event.Timestamp.IsZero() {
+				attrs = append(attrs, newXESAttribute("date", XESKeyTimestamp, event.Timestamp.Format(xesDateFormat)))
+			}
+
+			xTrace.Events = append(xTrace.Events, xesEvent{Attributes: append(attrs, buildXESAttributes(event.Attributes)...)})
+		}
+
+		doc.Traces = append(doc.Traces, xTrace)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+func newXESAttribute(attrType string, key string, value string) xesAttribute {
+	return xesAttribute{XMLName: xml.Name{Local: attrType}, Key: key, Value: value}
+}
+
+// buildXESAttributes returns string attributes sorted by key.
+func buildXESAttributes(attributes map[string]string) []xesAttribute {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	res := make([]xesAttribute, 0, len(keys))
+	for _, key := range keys {
+		res = append(res, newXESAttribute("string", key, attributes[key]))
+	}
+
+	return res
+}
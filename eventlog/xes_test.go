@@ -0,0 +1,123 @@
+package eventlog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet/state"
+)
+
+const testXES = `<?xml version="1.0" encoding="UTF-8"?>
+<log xes.version="1.0" xmlns="http://www.xes-standard.org/">
+  <extension name="Concept" prefix="concept" uri="http://www.xes-standard.org/concept.xesext"></extension>
+  <extension name="Time" prefix="time" uri="http://www.xes-standard.org/time.xesext"></extension>
+  <trace>
+    <string key="concept:name" value="1"></string>
+    <string key="customer" value="bob"></string>
+    <event>
+      <string key="concept:name" value="submit"></string>
+      <date key="time:timestamp" value="2020-01-01T10:00:00.000Z"></date>
+      <string key="user" value="alice"></string>
+    </event>
+    <event>
+      <string key="concept:name" value="approve"></string>
+    </event>
+  </trace>
+</log>
+`
+
+func newTestXESLog() *Log {
+	return NewLog(Trace{
+		CaseID:     "1",
+		Attributes: map[string]string{"customer": "bob"},
+		Events: []Event{
+			{
+				Activity:   "submit",
+				Timestamp:  time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+				Attributes: map[string]string{"user": "alice"},
+			},
+			{Activity: "approve", Attributes: map[string]string{}},
+		},
+	})
+}
+
+func TestWriteXES_ExpectedDocument(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteXES(&buf, newTestXESLog()))
+	assert.Equal(t, testXES, buf.String())
+}
+
+func TestReadXES_ExpectedLog(t *testing.T) {
+	log, err := ReadXES(strings.NewReader(testXES))
+
+	require.NoError(t, err)
+	assert.Equal(t, newTestXESLog(), log)
+}
+
+func TestReadXES_OtherTypesAndNamespace_ValuesAsStrings(t *testing.T) {
+	log, err := ReadXES(strings.NewReader(`<log xes.version="2.0">
+  <global scope="event"><string key="concept:name" value="x"/></global>
+  <trace>
+    <string key="concept:name" value="case"/>
+    <event>
+      <string key="concept:name" value="a"/>
+      <int key="cost" value="10"/>
+      <boolean key="auto" value="true"/>
+      <date key="time:timestamp" value="2020-01-01T10:00:00+02:00"/>
+    </event>
+  </trace>
+</log>`))
+
+	require.NoError(t, err)
+	require.Len(t, log.Traces, 1)
+	assert.Equal(t, "case", log.Traces[0].CaseID)
+	assert.Equal(t, map[string]string{"cost": "10", "auto": "true"}, log.Traces[0].Events[0].Attributes)
+	assert.True(t, time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC).Equal(log.Traces[0].Events[0].Timestamp))
+}
+
+func TestReadXES_BadTimestamp_ExpectedErr(t *testing.T) {
+	_, err := ReadXES(strings.NewReader(`<log><trace><event>` +
+		`<string key="concept:name" value="a"/><date key="time:timestamp" value="yesterday"/>` +
+		`</event></trace></log>`))
+
+	assert.True(t, state.ErrorIs(ErrCodeXESBadTimestamp, err))
+	assert.Contains(t, err.Error(), "Bad timestamp of event 'a'")
+}
+
+func TestReadXES_BadXML_ExpectedErr(t *testing.T) {
+	_, err := ReadXES(strings.NewReader("<log>"))
+
+	assert.Error(t, err)
+}
+
+func TestNewTraceFromHistory_ExpectedTrace(t *testing.T) {
+	at := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	trace := NewTraceFromHistory(
+		"1",
+		[]state.HistoryEntry{
+			{TransitionID: "submit", From: []string{"start"}, To: []string{"review"}, At: at},
+			{TransitionID: "approve", From: []string{"review"}, To: []string{"finish"}, At: at.Add(time.Hour)},
+		},
+		map[string]string{"customer": "bob"},
+	)
+
+	assert.Equal(
+		t,
+		Trace{
+			CaseID:     "1",
+			Attributes: map[string]string{"customer": "bob"},
+			Events: []Event{
+				{Activity: "submit", Timestamp: at},
+				{Activity: "approve", Timestamp: at.Add(time.Hour)},
+			},
+		},
+		trace,
+	)
+}
@@ -0,0 +1,30 @@
+package gowfnet
+
+import "context"
+
+// FailureListenerInterface is an optional extension of ListenerInterface.
+//
+// It is called when Start or a transition fails after BeforeStart or BeforeTransition is called,
+// the error of the before method included. The methods are called instead of AfterStart and AfterTransition.
+// Listeners which don't implement the interface get AfterStart if BeforeStart succeeded,
+// AfterTransition isn't called for failed transitions.
+type FailureListenerInterface interface {
+	OnStartFailure(ctx context.Context, err error)
+	OnTransitionFailure(ctx context.Context, transitionID string, state StateOpInterface, err error)
+}
+
+func (n *Net) notifyStartFailure(ctx context.Context, err error) error {
+	if listener, ok := n.listener.(FailureListenerInterface); ok {
+		listener.OnStartFailure(ctx, err)
+	}
+
+	return err
+}
+
+func (n *Net) notifyTransitionFailure(ctx context.Context, transitionID string, s StateOpInterface, err error) error {
+	if listener, ok := n.listener.(FailureListenerInterface); ok {
+		listener.OnTransitionFailure(ctx, transitionID, s, err)
+	}
+
+	return err
+}
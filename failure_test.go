@@ -0,0 +1,167 @@
+package gowfnet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/state"
+)
+
+type testingFailureListener struct {
+	*testingFailingListener
+	startErr           error
+	startFailures      []error
+	afterStarts        int
+	transitionFailures map[string]error
+}
+
+func newTestingFailureListener(startErr error, transitionErr error) *testingFailureListener {
+	return &testingFailureListener{
+		testingFailingListener: &testingFailingListener{StubListener: NewStubListener(), transitionErr: transitionErr},
+		startErr:               startErr,
+		transitionFailures:     make(map[string]error),
+	}
+}
+
+func (l *testingFailureListener) BeforeStart(ctx context.Context) error {
+	return l.startErr
+}
+
+func (l *testingFailureListener) AfterStart(ctx context.Context) {
+	l.afterStarts++
+}
+
+func (l *testingFailureListener) OnStartFailure(ctx context.Context, err error) {
+	l.startFailures = append(l.startFailures, err)
+}
+
+func (l *testingFailureListener) OnTransitionFailure(
+	ctx context.Context,
+	transitionID string,
+	s StateOpInterface,
+	err error,
+) {
+	l.transitionFailures[transitionID] = err
+}
+
+type testingMoveFailingStateListener struct {
+	*state.StubListener
+	err error
+}
+
+func (l *testingMoveFailingStateListener) BeforeMove(
+	ctx context.Context,
+	st state.OpInterface,
+	from []string,
+	to []string,
+) error {
+	return l.err
+}
+
+func TestNet_Start_BeforeStartErr_FailureListenerIsCalled(t *testing.T) {
+	eErr := errors.New("a")
+	listener := newTestingFailureListener(eErr, nil)
+
+	net := NewNet(cfg.Minimal{
+		Start:       "start",
+		Finish:      "finish",
+		Places:      []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{"t": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}}},
+	})
+	net.WithListener(listener)
+
+	err := net.Start(context.Background(), state.NewState())
+	assert.Same(t, eErr, err)
+	assert.Equal(t, []error{eErr}, listener.startFailures)
+	assert.Zero(t, listener.afterStarts)
+}
+
+func TestNet_Start_StateErr_FailureListenerIsCalled(t *testing.T) {
+	eErr := errors.New("a")
+	listener := newTestingFailureListener(nil, nil)
+	listener.stateListener = &testingMoveFailingStateListener{StubListener: state.NewStubListener(), err: eErr}
+
+	net := NewNet(cfg.Minimal{
+		Start:       "start",
+		Finish:      "finish",
+		Places:      []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{"t": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}}},
+	})
+	net.WithListener(listener)
+
+	err := net.Start(context.Background(), state.NewState())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, eErr))
+	assert.Equal(t, []error{err}, listener.startFailures)
+	assert.Zero(t, listener.afterStarts, "OnStartFailure is called instead of AfterStart")
+}
+
+func TestNet_Start_StateErr_AfterStartOfListenerWithoutFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	eErr := errors.New("a")
+	st := state.NewState()
+
+	listener := NewMockListenerInterface(ctrl)
+	listener.EXPECT().BeforeStart(startCtx(st)).Return(nil)
+	listener.EXPECT().HasStateListener().Return(true).AnyTimes()
+	listener.EXPECT().
+		GetStateListener().
+		Return(&testingMoveFailingStateListener{StubListener: state.NewStubListener(), err: eErr}).
+		AnyTimes()
+	listener.EXPECT().AfterStart(startCtx(st))
+
+	net := NewNet(cfg.Minimal{
+		Start:       "start",
+		Finish:      "finish",
+		Places:      []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{"t": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}}},
+	})
+	net.WithListener(listener)
+
+	assert.True(t, errors.Is(net.Start(context.Background(), st), eErr))
+}
+
+func TestNet_Start_Success_AfterStartIsCalled(t *testing.T) {
+	listener := newTestingFailureListener(nil, nil)
+
+	net := NewNet(cfg.Minimal{
+		Start:       "start",
+		Finish:      "finish",
+		Places:      []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{"t": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}}},
+	})
+	net.WithListener(listener)
+
+	require.NoError(t, net.Start(context.Background(), state.NewState()))
+	assert.Empty(t, listener.startFailures)
+	assert.Equal(t, 1, listener.afterStarts)
+}
+
+func TestNet_Transit_BeforeTransitionErr_FailureListenerGetsRoutedErr(t *testing.T) {
+	listener := newTestingFailureListener(nil, errors.New("a"))
+	net, st := newErrorPlaceNet(t, listener)
+
+	err := net.Transit(context.Background(), st, "send")
+	require.Error(t, err)
+	assert.Equal(t, map[string]error{"send": err}, listener.transitionFailures)
+}
+
+func TestNet_Transit_StateErr_FailureListenerIsCalled(t *testing.T) {
+	listener := newTestingFailureListener(nil, nil)
+	net, st := newErrorPlaceNet(t, listener)
+
+	err := net.Transit(context.Background(), st, "retry")
+	assert.True(t, errors.Is(err, state.ErrStateHasNotTokenInPlace))
+	assert.Equal(t, map[string]error{"retry": err}, listener.transitionFailures)
+
+	require.NoError(t, net.Transit(context.Background(), st, "send"))
+	assert.Len(t, listener.transitionFailures, 1)
+}
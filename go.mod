@@ -1,6 +1,6 @@
 module github.com/andrskom/gowfnet
 
-go 1.20
+go 1.21
 
 require (
 	github.com/golang/mock v1.4.4
@@ -34,6 +34,14 @@ func (s *State) AfterMove(ctx context.Context, st state.OpInterface, from []stri
 	s.eventChan <- "moved_" + s.printFromTo(from, to)
 }
 
+func (s *State) OnCancel(ctx context.Context, st state.OpInterface, places []string) {
+	s.eventChan <- fmt.Sprintf("cancelled_%+v", places)
+}
+
+func (s *State) OnRevert(ctx context.Context, st state.OpInterface, entry state.HistoryEntry) {
+	s.eventChan <- "reverted_" + entry.TransitionID
+}
+
 func (s *State) printFromTo(from []string, to []string) string {
 	return fmt.Sprintf("FROM:%+v_TO:%+v", from, to)
 }
@@ -66,6 +74,16 @@ func (l *Listener) AfterTransition(ctx context.Context, transitionID string, sta
 	l.eventChan <- transitionID + "_transited"
 }
 
+func (l *Listener) BeforeUndo(ctx context.Context, transitionID string, state gowfnet.StateOpInterface) error {
+	l.eventChan <- "undo_" + transitionID
+
+	return nil
+}
+
+func (l *Listener) AfterUndo(ctx context.Context, transitionID string, state gowfnet.StateOpInterface) {
+	l.eventChan <- transitionID + "_undone"
+}
+
 func (l *Listener) HasStateListener() bool {
 	return true
 }
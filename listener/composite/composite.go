@@ -0,0 +1,332 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/andrskom/gowfnet"
+	"github.com/andrskom/gowfnet/state"
+)
+
+// Policy of handling of errors of Before* methods of children.
+type Policy int
+
+const (
+	// PolicyStop returns the first error, next children are not called.
+	PolicyStop Policy = iota
+	// PolicyContinue calls all children and returns their errors joined by errors.Join.
+	PolicyContinue
+)
+
+// Listener calls children net listeners in order of adding.
+//
+// Optional interfaces of the net, e.g. gowfnet.UndoListenerInterface, are called for children which implement them.
+// State listeners of children are merged into State with the same policy.
+//
+// If a Before* method fails, failures are forwarded only to children which are called by it,
+// see gowfnet.FailureListenerInterface. Children which don't implement it get AfterStart if their BeforeStart succeeded.
+type Listener struct {
+	listeners []gowfnet.ListenerInterface
+	policy    Policy
+	mu        sync.Mutex
+	// failedCalls keeps results of children of the failed Before* call until the failure is reported.
+	failedCalls map[callKey][]bool
+}
+
+// callKey is ctx and arguments of Before* call, the net passes its own ctx for each start and transition.
+type callKey struct {
+	ctx          context.Context
+	transitionID string
+	state        gowfnet.StateOpInterface
+}
+
+// New init listener with children, errors are handled by PolicyStop.
+func New(listeners ...gowfnet.ListenerInterface) *Listener {
+	return &Listener{listeners: listeners, policy: PolicyStop}
+}
+
+// WithPolicy set policy of handling of errors.
+func (l *Listener) WithPolicy(policy Policy) *Listener {
+	l.policy = policy
+
+	return l
+}
+
+// Add children to the end of the list.
+func (l *Listener) Add(listeners ...gowfnet.ListenerInterface) *Listener {
+	l.listeners = append(l.listeners, listeners...)
+
+	return l
+}
+
+func (l *Listener) BeforeStart(ctx context.Context) error {
+	errs := newErrs(l.policy)
+	succeeded := make([]bool, 0, len(l.listeners))
+
+	for _, listener := range l.listeners {
+		err := listener.BeforeStart(ctx)
+		succeeded = append(succeeded, err == nil)
+
+		if errs.add(err) {
+			break
+		}
+	}
+
+	return l.saveFailedCall(callKey{ctx: ctx}, succeeded, errs.get())
+}
+
+func (l *Listener) AfterStart(ctx context.Context) {
+	for _, listener := range l.listeners {
+		listener.AfterStart(ctx)
+	}
+}
+
+func (l *Listener) BeforeTransition(ctx context.Context, transitionID string, st gowfnet.StateOpInterface) error {
+	errs := newErrs(l.policy)
+	succeeded := make([]bool, 0, len(l.listeners))
+
+	for _, listener := range l.listeners {
+		err := listener.BeforeTransition(ctx, transitionID, st)
+		succeeded = append(succeeded, err == nil)
+
+		if errs.add(err) {
+			break
+		}
+	}
+
+	return l.saveFailedCall(callKey{ctx: ctx, transitionID: transitionID, state: st}, succeeded, errs.get())
+}
+
+func (l *Listener) AfterTransition(ctx context.Context, transitionID string, st gowfnet.StateOpInterface) {
+	for _, listener := range l.listeners {
+		listener.AfterTransition(ctx, transitionID, st)
+	}
+}
+
+func (l *Listener) BeforeUndo(ctx context.Context, transitionID string, st gowfnet.StateOpInterface) error {
+	errs := newErrs(l.policy)
+
+	for _, listener := range l.listeners {
+		if undoListener, ok := listener.(gowfnet.UndoListenerInterface); ok {
+			if errs.add(undoListener.BeforeUndo(ctx, transitionID, st)) {
+				break
+			}
+		}
+	}
+
+	return errs.get()
+}
+
+func (l *Listener) AfterUndo(ctx context.Context, transitionID string, st gowfnet.StateOpInterface) {
+	for _, listener := range l.listeners {
+		if undoListener, ok := listener.(gowfnet.UndoListenerInterface); ok {
+			undoListener.AfterUndo(ctx, transitionID, st)
+		}
+	}
+}
+
+// OnStartFailure is called instead of AfterStart, so children which don't implement
+// gowfnet.FailureListenerInterface get AfterStart if their BeforeStart succeeded.
+func (l *Listener) OnStartFailure(ctx context.Context, err error) {
+	succeeded := l.popFailedCall(callKey{ctx: ctx})
+
+	for i, listener := range l.listeners[:len(succeeded)] {
+		if failureListener, ok := listener.(gowfnet.FailureListenerInterface); ok {
+			failureListener.OnStartFailure(ctx, err)
+		} else if succeeded[i] {
+			listener.AfterStart(ctx)
+		}
+	}
+}
+
+func (l *Listener) OnTransitionFailure(
+	ctx context.Context,
+	transitionID string,
+	st gowfnet.StateOpInterface,
+	err error,
+) {
+	succeeded := l.popFailedCall(callKey{ctx: ctx, transitionID: transitionID, state: st})
+
+	for _, listener := range l.listeners[:len(succeeded)] {
+		if failureListener, ok := listener.(gowfnet.FailureListenerInterface); ok {
+			failureListener.OnTransitionFailure(ctx, transitionID, st, err)
+		}
+	}
+}
+
+func (l *Listener) OnSubprocessRemoval(ctx context.Context, transitionID string, st gowfnet.StateOpInterface) {
+	for _, listener := range l.listeners {
+		if subprocessListener, ok := listener.(gowfnet.SubprocessListenerInterface); ok {
+			subprocessListener.OnSubprocessRemoval(ctx, transitionID, st)
+		}
+	}
+}
+
+// HasStateListener returns true if any child has the state listener.
+func (l *Listener) HasStateListener() bool {
+	for _, listener := range l.listeners {
+		if listener.HasStateListener() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetStateListener returns state listeners of children merged into State.
+func (l *Listener) GetStateListener() state.ListenerInterface {
+	res := NewState().WithPolicy(l.policy)
+
+	for _, listener := range l.listeners {
+		if listener.HasStateListener() {
+			res.Add(listener.GetStateListener())
+		}
+	}
+
+	return res
+}
+
+// State calls children state listeners in order of adding.
+//
+// Optional interfaces of the state, e.g. state.CancelListenerInterface, are called for children which implement them.
+type State struct {
+	listeners []state.ListenerInterface
+	policy    Policy
+}
+
+// NewState init state listener with children, errors are handled by PolicyStop.
+func NewState(listeners ...state.ListenerInterface) *State {
+	return &State{listeners: listeners, policy: PolicyStop}
+}
+
+// WithPolicy set policy of handling of errors.
+func (s *State) WithPolicy(policy Policy) *State {
+	s.policy = policy
+
+	return s
+}
+
+// Add children to the end of the list.
+func (s *State) Add(listeners ...state.ListenerInterface) *State {
+	s.listeners = append(s.listeners, listeners...)
+
+	return s
+}
+
+func (s *State) OnFinish(st state.OpInterface) {
+	for _, listener := range s.listeners {
+		listener.OnFinish(st)
+	}
+}
+
+func (s *State) OnError(st state.OpInterface) {
+	for _, listener := range s.listeners {
+		listener.OnError(st)
+	}
+}
+
+func (s *State) BeforeMove(ctx context.Context, st state.OpInterface, from []string, to []string) error {
+	errs := newErrs(s.policy)
+
+	for _, listener := range s.listeners {
+		if errs.add(listener.BeforeMove(ctx, st, from, to)) {
+			break
+		}
+	}
+
+	return errs.get()
+}
+
+func (s *State) AfterMove(ctx context.Context, st state.OpInterface, from []string, to []string) {
+	for _, listener := range s.listeners {
+		listener.AfterMove(ctx, st, from, to)
+	}
+}
+
+func (s *State) OnCancel(ctx context.Context, st state.OpInterface, places []string) {
+	for _, listener := range s.listeners {
+		if cancelListener, ok := listener.(state.CancelListenerInterface); ok {
+			cancelListener.OnCancel(ctx, st, places)
+		}
+	}
+}
+
+func (s *State) OnRevert(ctx context.Context, st state.OpInterface, entry state.HistoryEntry) {
+	for _, listener := range s.listeners {
+		if revertListener, ok := listener.(state.RevertListenerInterface); ok {
+			revertListener.OnRevert(ctx, st, entry)
+		}
+	}
+}
+
+// saveFailedCall keeps results of children if the call is failed, returns err as is.
+func (l *Listener) saveFailedCall(key callKey, succeeded []bool, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.failedCalls == nil {
+		l.failedCalls = make(map[callKey][]bool)
+	}
+
+	l.failedCalls[key] = succeeded
+
+	return err
+}
+
+// popFailedCall returns results of children of the failed call,
+// all children are succeeded if the failure isn't caused by the call.
+func (l *Listener) popFailedCall(key callKey) []bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if succeeded, ok := l.failedCalls[key]; ok {
+		delete(l.failedCalls, key)
+
+		return succeeded
+	}
+
+	succeeded := make([]bool, len(l.listeners))
+	for i := range succeeded {
+		succeeded[i] = true
+	}
+
+	return succeeded
+}
+
+// errs aggregates errors of children according to the policy.
+type errs struct {
+	policy Policy
+	errs   []error
+}
+
+func newErrs(policy Policy) *errs {
+	return &errs{policy: policy}
+}
+
+// add the err, returns true if next children must not be called.
+func (e *errs) add(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	e.errs = append(e.errs, err)
+
+	return e.policy == PolicyStop
+}
+
+// get returns nil, the only err as is or errors joined by errors.Join.
+func (e *errs) get() error {
+	switch len(e.errs) {
+	case 0:
+		return nil
+	case 1:
+		return e.errs[0]
+	default:
+		return errors.Join(e.errs...)
+	}
+}
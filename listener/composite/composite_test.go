@@ -0,0 +1,337 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet"
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/state"
+)
+
+type calls struct {
+	list []string
+}
+
+func (c *calls) add(name string, event string) {
+	c.list = append(c.list, name+"."+event)
+}
+
+type testListener struct {
+	*gowfnet.StubListener
+	name  string
+	calls *calls
+	err   error
+	state bool
+}
+
+func (l *testListener) BeforeStart(ctx context.Context) error {
+	l.calls.add(l.name, "beforeStart")
+
+	return l.err
+}
+
+func (l *testListener) AfterStart(ctx context.Context) {
+	l.calls.add(l.name, "afterStart")
+}
+
+func (l *testListener) BeforeTransition(ctx context.Context, transitionID string, st gowfnet.StateOpInterface) error {
+	l.calls.add(l.name, "beforeTransition")
+
+	return l.err
+}
+
+func (l *testListener) AfterTransition(ctx context.Context, transitionID string, st gowfnet.StateOpInterface) {
+	l.calls.add(l.name, "afterTransition")
+}
+
+func (l *testListener) HasStateListener() bool {
+	return l.state
+}
+
+func (l *testListener) GetStateListener() state.ListenerInterface {
+	return &testStateListener{StubListener: state.NewStubListener(), name: l.name, calls: l.calls}
+}
+
+type testFailureListener struct {
+	*testListener
+}
+
+func (l *testFailureListener) OnStartFailure(ctx context.Context, err error) {
+	l.calls.add(l.name, "startFailure")
+}
+
+func (l *testFailureListener) OnTransitionFailure(
+	ctx context.Context,
+	transitionID string,
+	st gowfnet.StateOpInterface,
+	err error,
+) {
+	l.calls.add(l.name, "transitionFailure")
+}
+
+type testStateListener struct {
+	*state.StubListener
+	name  string
+	calls *calls
+	err   error
+}
+
+func (l *testStateListener) OnFinish(st state.OpInterface) {
+	l.calls.add(l.name, "finish")
+}
+
+func (l *testStateListener) BeforeMove(ctx context.Context, st state.OpInterface, from []string, to []string) error {
+	l.calls.add(l.name, "beforeMove")
+
+	return l.err
+}
+
+func (l *testStateListener) AfterMove(ctx context.Context, st state.OpInterface, from []string, to []string) {
+	l.calls.add(l.name, "afterMove")
+}
+
+type testCancelListener struct {
+	*state.StubListener
+	cancelled *[]string
+}
+
+func (l *testCancelListener) OnCancel(ctx context.Context, st state.OpInterface, places []string) {
+	*l.cancelled = append(*l.cancelled, places...)
+}
+
+func (l *testCancelListener) OnRevert(ctx context.Context, st state.OpInterface, entry state.HistoryEntry) {
+	*l.cancelled = append(*l.cancelled, "revert_"+entry.TransitionID)
+}
+
+type testNotCancelStateListener struct {
+	state.ListenerInterface
+}
+
+func newTestListener(name string, c *calls, err error, hasState bool) *testListener {
+	return &testListener{StubListener: gowfnet.NewStubListener(), name: name, calls: c, err: err, state: hasState}
+}
+
+func newNet(listener gowfnet.ListenerInterface) *gowfnet.Net {
+	net := gowfnet.NewNet(cfg.Minimal{
+		Start:       "start",
+		Finish:      "finish",
+		Places:      []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{"approve": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}}},
+	})
+	net.WithListener(listener)
+
+	return net
+}
+
+func TestListener_Net_ChildrenAreCalledInOrder(t *testing.T) {
+	c := &calls{}
+	net := newNet(New(newTestListener("a", c, nil, true), newTestListener("b", c, nil, false)).
+		Add(newTestListener("c", c, nil, true)))
+
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+	require.NoError(t, net.Transit(context.Background(), st, "approve"))
+
+	assert.Equal(
+		t,
+		[]string{
+			"a.beforeStart", "b.beforeStart", "c.beforeStart",
+			"a.beforeMove", "c.beforeMove", "a.afterMove", "c.afterMove",
+			"a.afterStart", "b.afterStart", "c.afterStart",
+			"a.beforeTransition", "b.beforeTransition", "c.beforeTransition",
+			"a.beforeMove", "c.beforeMove", "a.afterMove", "c.afterMove",
+			"a.finish", "c.finish",
+			"a.afterTransition", "b.afterTransition", "c.afterTransition",
+		},
+		c.list,
+	)
+}
+
+func TestListener_BeforeStart_PolicyStop_FirstErrIsReturned(t *testing.T) {
+	c := &calls{}
+	eErr := errors.New("a")
+	l := New(
+		newTestListener("a", c, eErr, false),
+		newTestListener("b", c, errors.New("b"), false),
+	)
+
+	assert.Same(t, eErr, l.BeforeStart(context.Background()))
+	assert.Equal(t, []string{"a.beforeStart"}, c.list)
+}
+
+func TestListener_BeforeTransition_PolicyContinue_ErrsAreJoined(t *testing.T) {
+	c := &calls{}
+	errA := errors.New("a")
+	errC := state.NewError(state.ErrCodeStateIsFinished, "c")
+	l := New(
+		newTestListener("a", c, errA, false),
+		newTestListener("b", c, nil, false),
+		newTestListener("c", c, errC, false),
+	).WithPolicy(PolicyContinue)
+
+	err := l.BeforeTransition(context.Background(), "t", state.NewState())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errA))
+	assert.True(t, errors.Is(err, state.ErrStateIsFinished))
+	assert.Equal(t, []string{"a.beforeTransition", "b.beforeTransition", "c.beforeTransition"}, c.list)
+}
+
+func TestListener_BeforeTransition_PolicyContinue_OneErrIsReturnedAsIs(t *testing.T) {
+	c := &calls{}
+	eErr := errors.New("a")
+	l := New(newTestListener("a", c, nil, false), newTestListener("b", c, eErr, false)).WithPolicy(PolicyContinue)
+
+	assert.Same(t, eErr, l.BeforeTransition(context.Background(), "t", state.NewState()))
+}
+
+func TestListener_HasStateListener_WithoutStateListeners_ReturnsFalse(t *testing.T) {
+	c := &calls{}
+
+	assert.False(t, New().HasStateListener())
+	assert.False(t, New(newTestListener("a", c, nil, false)).HasStateListener())
+	assert.True(t, New(newTestListener("a", c, nil, false), newTestListener("b", c, nil, true)).HasStateListener())
+}
+
+func TestListener_GetStateListener_PolicyIsUsed(t *testing.T) {
+	c := &calls{}
+	l := New(newTestListener("a", c, nil, true)).WithPolicy(PolicyContinue)
+
+	assert.Equal(t, PolicyContinue, l.GetStateListener().(*State).policy)
+}
+
+func TestListener_Failures_OnlyImplementersAreCalled(t *testing.T) {
+	c := &calls{}
+	eErr := errors.New("a")
+	net := newNet(New(
+		&testFailureListener{testListener: newTestListener("a", c, eErr, false)},
+		newTestListener("b", c, nil, false),
+	).WithPolicy(PolicyContinue))
+
+	st := state.NewState()
+	assert.Same(t, eErr, net.Start(context.Background(), st))
+	assert.Equal(t, []string{"a.beforeStart", "b.beforeStart", "a.startFailure", "b.afterStart"}, c.list)
+
+	c.list = nil
+	l := New(&testFailureListener{testListener: newTestListener("a", c, nil, false)}, newTestListener("b", c, nil, false))
+	l.OnTransitionFailure(context.Background(), "t", st, eErr)
+	assert.Equal(t, []string{"a.transitionFailure"}, c.list)
+}
+
+func TestListener_BeforeStart_Err_FailureIsForwardedToCalledChildren(t *testing.T) {
+	c := &calls{}
+	eErr := errors.New("a")
+	net := newNet(New(
+		&testFailureListener{testListener: newTestListener("a", c, nil, false)},
+		newTestListener("b", c, nil, false),
+		&testFailureListener{testListener: newTestListener("c", c, eErr, false)},
+		&testFailureListener{testListener: newTestListener("d", c, nil, false)},
+		newTestListener("e", c, nil, false),
+	))
+
+	assert.Same(t, eErr, net.Start(context.Background(), state.NewState()))
+	assert.Equal(
+		t,
+		[]string{
+			"a.beforeStart", "b.beforeStart", "c.beforeStart",
+			"a.startFailure", "b.afterStart", "c.startFailure",
+		},
+		c.list,
+	)
+}
+
+func TestListener_BeforeStart_ErrOfChildWithoutFailures_AfterStartIsNotCalled(t *testing.T) {
+	c := &calls{}
+	eErr := errors.New("a")
+	l := New(newTestListener("a", c, nil, false), newTestListener("b", c, eErr, false)).WithPolicy(PolicyContinue)
+
+	assert.Same(t, eErr, newNet(l).Start(context.Background(), state.NewState()))
+	assert.Equal(t, []string{"a.beforeStart", "b.beforeStart", "a.afterStart"}, c.list)
+	assert.Empty(t, l.failedCalls)
+}
+
+func TestListener_OnStartFailure_AfterStartOfChildrenWithoutFailures(t *testing.T) {
+	c := &calls{}
+	l := New(&testFailureListener{testListener: newTestListener("a", c, nil, false)}, newTestListener("b", c, nil, false))
+
+	l.OnStartFailure(context.Background(), errors.New("a"))
+	assert.Equal(t, []string{"a.startFailure", "b.afterStart"}, c.list)
+}
+
+func TestListener_BeforeTransition_Err_FailureIsForwardedToCalledChildren(t *testing.T) {
+	c := &calls{}
+	eErr := errors.New("a")
+	l := New(
+		&testFailureListener{testListener: newTestListener("a", c, nil, false)},
+		&testFailureListener{testListener: newTestListener("b", c, nil, false)},
+	)
+	net := newNet(l)
+
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+
+	l.Add(
+		&testFailureListener{testListener: newTestListener("c", c, eErr, false)},
+		&testFailureListener{testListener: newTestListener("d", c, nil, false)},
+	)
+	c.list = nil
+
+	assert.Same(t, eErr, net.Transit(context.Background(), st, "approve"))
+	assert.Equal(
+		t,
+		[]string{
+			"a.beforeTransition", "b.beforeTransition", "c.beforeTransition",
+			"a.transitionFailure", "b.transitionFailure", "c.transitionFailure",
+		},
+		c.list,
+	)
+	assert.Empty(t, l.failedCalls)
+}
+
+type testSubprocessListener struct {
+	*testListener
+}
+
+func (l *testSubprocessListener) OnSubprocessRemoval(
+	ctx context.Context,
+	transitionID string,
+	st gowfnet.StateOpInterface,
+) {
+	l.calls.add(l.name, "subprocessRemoval")
+}
+
+func TestListener_OnSubprocessRemoval_OnlyImplementersAreCalled(t *testing.T) {
+	c := &calls{}
+	l := New(&testSubprocessListener{testListener: newTestListener("a", c, nil, false)}, newTestListener("b", c, nil, false))
+
+	l.OnSubprocessRemoval(context.Background(), "t", state.NewState())
+	assert.Equal(t, []string{"a.subprocessRemoval"}, c.list)
+}
+
+func TestState_BeforeMove_PolicyStop_FirstErrIsReturned(t *testing.T) {
+	c := &calls{}
+	eErr := errors.New("a")
+	s := NewState(
+		&testStateListener{StubListener: state.NewStubListener(), name: "a", calls: c, err: eErr},
+		&testStateListener{StubListener: state.NewStubListener(), name: "b", calls: c},
+	)
+
+	assert.Same(t, eErr, s.BeforeMove(context.Background(), state.NewState(), nil, nil))
+	assert.Equal(t, []string{"a.beforeMove"}, c.list)
+}
+
+func TestState_OnCancelAndOnRevert_OnlyImplementersAreCalled(t *testing.T) {
+	var cancelled []string
+
+	cancelListener := &testCancelListener{StubListener: state.NewStubListener(), cancelled: &cancelled}
+	s := NewState(&testNotCancelStateListener{ListenerInterface: cancelListener}, cancelListener)
+
+	s.OnCancel(context.Background(), state.NewState(), []string{"a"})
+	s.OnRevert(context.Background(), state.NewState(), state.HistoryEntry{TransitionID: "t"})
+
+	assert.Equal(t, []string{"a", "revert_t"}, cancelled)
+}
@@ -0,0 +1,220 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"github.com/andrskom/gowfnet"
+	"github.com/andrskom/gowfnet/state"
+)
+
+// Event of the net or the state which is logged.
+type Event string
+
+const (
+	EventBeforeStart       Event = "beforeStart"
+	EventAfterStart        Event = "afterStart"
+	EventStartFailure      Event = "startFailure"
+	EventBeforeTransition  Event = "beforeTransition"
+	EventAfterTransition   Event = "afterTransition"
+	EventTransitionFailure Event = "transitionFailure"
+	EventError             Event = "error"
+	EventFinish            Event = "finish"
+	EventBeforeMove        Event = "beforeMove"
+	EventAfterMove         Event = "afterMove"
+	EventCancel            Event = "cancel"
+	EventRevert            Event = "revert"
+)
+
+// Keys of attributes of records.
+const (
+	KeyEvent      = "event"
+	KeyTransition = "transition"
+	KeyFrom       = "from"
+	KeyTo         = "to"
+	KeyPlaces     = "places"
+	KeyFinished   = "finished"
+	KeyError      = "error"
+	KeyErrorCode  = "errorCode"
+)
+
+// SubjectExtractor builds attributes of the subject of ctx, see gowfnet.SetSubject.
+type SubjectExtractor func(subject interface{}) []slog.Attr
+
+// Listener writes slog records for events of the net and its states.
+//
+// Default levels are debug for Before* and moves, info for After*, finishing, cancellation and revert
+// and error for failures and errors of the state.
+// Attributes of the subject are added only if the extractor is set.
+// OnError and OnFinish of the state have no ctx, so their records are without the subject.
+type Listener struct {
+	logger    *slog.Logger
+	levels    map[Event]slog.Level
+	extractor SubjectExtractor
+}
+
+// New init listener with the logger.
+func New(logger *slog.Logger) *Listener {
+	return &Listener{
+		logger: logger,
+		levels: map[Event]slog.Level{
+			EventBeforeStart:       slog.LevelDebug,
+			EventAfterStart:        slog.LevelInfo,
+			EventStartFailure:      slog.LevelError,
+			EventBeforeTransition:  slog.LevelDebug,
+			EventAfterTransition:   slog.LevelInfo,
+			EventTransitionFailure: slog.LevelError,
+			EventError:             slog.LevelError,
+			EventFinish:            slog.LevelInfo,
+			EventBeforeMove:        slog.LevelDebug,
+			EventAfterMove:         slog.LevelDebug,
+			EventCancel:            slog.LevelInfo,
+			EventRevert:            slog.LevelInfo,
+		},
+	}
+}
+
+// WithLevel set level of records of the event.
+func (l *Listener) WithLevel(event Event, level slog.Level) *Listener {
+	l.levels[event] = level
+
+	return l
+}
+
+// WithSubjectExtractor set extractor of attributes of the subject.
+func (l *Listener) WithSubjectExtractor(extractor SubjectExtractor) *Listener {
+	l.extractor = extractor
+
+	return l
+}
+
+func (l *Listener) BeforeStart(ctx context.Context) error {
+	l.log(ctx, EventBeforeStart, "gowfnet: starting state")
+
+	return nil
+}
+
+func (l *Listener) AfterStart(ctx context.Context) {
+	l.log(ctx, EventAfterStart, "gowfnet: state is started")
+}
+
+func (l *Listener) OnStartFailure(ctx context.Context, err error) {
+	l.log(ctx, EventStartFailure, "gowfnet: start is failed", errorAttrs(err)...)
+}
+
+func (l *Listener) BeforeTransition(ctx context.Context, transitionID string, st gowfnet.StateOpInterface) error {
+	l.log(ctx, EventBeforeTransition, "gowfnet: transiting", slog.String(KeyTransition, transitionID))
+
+	return nil
+}
+
+func (l *Listener) AfterTransition(ctx context.Context, transitionID string, st gowfnet.StateOpInterface) {
+	l.log(
+		ctx,
+		EventAfterTransition,
+		"gowfnet: transition is done",
+		slog.String(KeyTransition, transitionID),
+		slog.Bool(KeyFinished, st.IsFinished()),
+	)
+}
+
+func (l *Listener) OnTransitionFailure(
+	ctx context.Context,
+	transitionID string,
+	st gowfnet.StateOpInterface,
+	err error,
+) {
+	l.log(
+		ctx,
+		EventTransitionFailure,
+		"gowfnet: transition is failed",
+		append([]slog.Attr{slog.String(KeyTransition, transitionID)}, errorAttrs(err)...)...,
+	)
+}
+
+func (l *Listener) HasStateListener() bool {
+	return true
+}
+
+func (l *Listener) GetStateListener() state.ListenerInterface {
+	return l
+}
+
+// OnError logs the last error of the state.
+func (l *Listener) OnError(st state.OpInterface) {
+	errs := st.GetErrorStack().GetErrs()
+	if len(errs) == 0 {
+		return
+	}
+
+	l.log(context.Background(), EventError, "gowfnet: error is added to state", errorAttrs(&errs[len(errs)-1])...)
+}
+
+func (l *Listener) OnFinish(st state.OpInterface) {
+	places := st.GetPlaces()
+	sort.Strings(places)
+
+	l.log(context.Background(), EventFinish, "gowfnet: state is finished", slog.Any(KeyPlaces, places))
+}
+
+func (l *Listener) BeforeMove(ctx context.Context, st state.OpInterface, from []string, to []string) error {
+	l.log(ctx, EventBeforeMove, "gowfnet: moving tokens", moveAttrs(ctx, from, to)...)
+
+	return nil
+}
+
+func (l *Listener) AfterMove(ctx context.Context, st state.OpInterface, from []string, to []string) {
+	l.log(ctx, EventAfterMove, "gowfnet: tokens are moved", moveAttrs(ctx, from, to)...)
+}
+
+func (l *Listener) OnCancel(ctx context.Context, st state.OpInterface, places []string) {
+	l.log(ctx, EventCancel, "gowfnet: tokens are cancelled", slog.Any(KeyPlaces, places))
+}
+
+func (l *Listener) OnRevert(ctx context.Context, st state.OpInterface, entry state.HistoryEntry) {
+	l.log(
+		ctx,
+		EventRevert,
+		"gowfnet: transition is reverted",
+		slog.String(KeyTransition, entry.TransitionID),
+		slog.Any(KeyFrom, entry.To),
+		slog.Any(KeyTo, entry.From),
+	)
+}
+
+func (l *Listener) log(ctx context.Context, event Event, msg string, attrs ...slog.Attr) {
+	level := l.levels[event]
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	res := make([]slog.Attr, 0, len(attrs)+1)
+	res = append(res, slog.String(KeyEvent, string(event)))
+	res = append(res, attrs...)
+
+	if l.extractor != nil {
+		if subject, ok := gowfnet.GetSubject(ctx); ok {
+			res = append(res, l.extractor(subject)...)
+		}
+	}
+
+	l.logger.LogAttrs(ctx, level, msg, res...)
+}
+
+func moveAttrs(ctx context.Context, from []string, to []string) []slog.Attr {
+	res := make([]slog.Attr, 0, 3)
+
+	if transitionID, ok := state.GetTransitionID(ctx); ok {
+		res = append(res, slog.String(KeyTransition, transitionID))
+	}
+
+	return append(res, slog.Any(KeyFrom, from), slog.Any(KeyTo, to))
+}
+
+func errorAttrs(err error) []slog.Attr {
+	return []slog.Attr{
+		slog.String(KeyError, err.Error()),
+		slog.String(KeyErrorCode, string(state.BuildError(err).GetCode())),
+	}
+}
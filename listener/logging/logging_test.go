@@ -0,0 +1,128 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet"
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/state"
+)
+
+type order struct {
+	ID string
+}
+
+func newLogger(buf *bytes.Buffer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return a
+		},
+	}))
+}
+
+func newNet(listener gowfnet.ListenerInterface) *gowfnet.Net {
+	net := gowfnet.NewNet(cfg.Minimal{
+		Start:       "start",
+		Finish:      "finish",
+		Places:      []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{"approve": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}}},
+	})
+	net.WithListener(listener)
+
+	return net
+}
+
+func TestListener_Net_RecordsAreWritten(t *testing.T) {
+	var buf bytes.Buffer
+
+	listener := New(newLogger(&buf, slog.LevelDebug)).
+		WithSubjectExtractor(func(subject interface{}) []slog.Attr {
+			return []slog.Attr{slog.String("order", subject.(order).ID)}
+		})
+
+	ctx := gowfnet.SetSubject(context.Background(), order{ID: "1"})
+	st := state.NewState()
+	net := newNet(listener)
+
+	require.NoError(t, net.Start(ctx, st))
+	require.NoError(t, net.Transit(ctx, st, "approve"))
+
+	assert.Equal(
+		t,
+		[]string{
+			`level=DEBUG msg="gowfnet: starting state" event=beforeStart order=1`,
+			`level=DEBUG msg="gowfnet: moving tokens" event=beforeMove from=[] to=[start] order=1`,
+			`level=DEBUG msg="gowfnet: tokens are moved" event=afterMove from=[] to=[start] order=1`,
+			`level=INFO msg="gowfnet: state is started" event=afterStart order=1`,
+			`level=DEBUG msg="gowfnet: transiting" event=beforeTransition transition=approve order=1`,
+			`level=DEBUG msg="gowfnet: moving tokens" event=beforeMove transition=approve from=[start] to=[finish] order=1`,
+			`level=DEBUG msg="gowfnet: tokens are moved" event=afterMove transition=approve from=[start] to=[finish] order=1`,
+			`level=INFO msg="gowfnet: state is finished" event=finish places=[finish]`,
+			`level=INFO msg="gowfnet: transition is done" event=afterTransition transition=approve finished=true order=1`,
+		},
+		strings.Split(strings.TrimSpace(buf.String()), "\n"),
+	)
+}
+
+func TestListener_WithLevel_LevelsAreUsed(t *testing.T) {
+	var buf bytes.Buffer
+
+	listener := New(newLogger(&buf, slog.LevelInfo)).
+		WithLevel(EventAfterStart, slog.LevelDebug).
+		WithLevel(EventBeforeTransition, slog.LevelWarn)
+
+	st := state.NewState()
+	net := newNet(listener)
+
+	require.NoError(t, net.Start(context.Background(), st))
+	require.NoError(t, net.Transit(context.Background(), st, "approve"))
+
+	assert.Equal(
+		t,
+		[]string{
+			`level=WARN msg="gowfnet: transiting" event=beforeTransition transition=approve`,
+			`level=INFO msg="gowfnet: state is finished" event=finish places=[finish]`,
+			`level=INFO msg="gowfnet: transition is done" event=afterTransition transition=approve finished=true`,
+		},
+		strings.Split(strings.TrimSpace(buf.String()), "\n"),
+	)
+}
+
+func TestListener_Failures_ErrorsAreWritten(t *testing.T) {
+	var buf bytes.Buffer
+
+	st := state.NewState()
+	listener := New(newLogger(&buf, slog.LevelError))
+	st.WithListener(listener)
+
+	listener.OnTransitionFailure(
+		context.Background(),
+		"approve",
+		st,
+		state.NewError(state.ErrCodeStateHasNotTokenInPlace, "State has not token in place"),
+	)
+	st.AddError(errors.New("a"))
+
+	assert.Equal(
+		t,
+		[]string{
+			`level=ERROR msg="gowfnet: transition is failed" event=transitionFailure transition=approve ` +
+				`error="State has not token in place" errorCode=gowfnet.state.HasNotTokenInPlace`,
+			`level=ERROR msg="gowfnet: error is added to state" event=error error=a errorCode=gowfnet.unknown`,
+		},
+		strings.Split(strings.TrimSpace(buf.String()), "\n"),
+	)
+}
@@ -0,0 +1,192 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/andrskom/gowfnet"
+	"github.com/andrskom/gowfnet/clock"
+	"github.com/andrskom/gowfnet/state"
+)
+
+// Names of metrics of the listener.
+const (
+	MetricStarts        = "gowfnet_starts_total"
+	MetricTransitions   = "gowfnet_transitions_total"
+	MetricErrors        = "gowfnet_errors_total"
+	MetricFinishes      = "gowfnet_finishes_total"
+	MetricPlaceDuration = "gowfnet_place_duration_seconds"
+)
+
+// Names of labels of metrics.
+const (
+	LabelTransition = "transition"
+	LabelCode       = "code"
+	LabelPlace      = "place"
+)
+
+// Listener collects metrics of the net and its states.
+//
+// Starts and transitions are counted before they are done, failed ones are counted in errors by code.
+// Errors added to the state are counted too.
+// Time spent in a place is observed when the token leaves the place, it is measured between AfterMove calls.
+//
+// The listener keeps time of marking of places for each state until the state is finished or gets an error,
+// time in places of a state with an error isn't observed even if the error is resolved later.
+// Call Forget for states which are dropped without finish or error, otherwise they are kept in memory.
+type Listener struct {
+	metrics  Metrics
+	clock    clock.Clock
+	mu       sync.Mutex
+	markedAt map[state.OpInterface]map[string]time.Time
+}
+
+// New init listener with the metrics.
+func New(metrics Metrics) *Listener {
+	return &Listener{
+		metrics:  metrics,
+		clock:    clock.NewSystem(),
+		markedAt: make(map[state.OpInterface]map[string]time.Time),
+	}
+}
+
+// WithClock set clock which is used for measuring of time in places.
+func (l *Listener) WithClock(c clock.Clock) *Listener {
+	l.clock = c
+
+	return l
+}
+
+func (l *Listener) BeforeStart(ctx context.Context) error {
+	l.metrics.Inc(MetricStarts, nil)
+
+	return nil
+}
+
+func (l *Listener) AfterStart(ctx context.Context) {}
+
+func (l *Listener) BeforeTransition(ctx context.Context, transitionID string, st gowfnet.StateOpInterface) error {
+	l.metrics.Inc(MetricTransitions, Labels{LabelTransition: transitionID})
+
+	return nil
+}
+
+func (l *Listener) AfterTransition(ctx context.Context, transitionID string, st gowfnet.StateOpInterface) {
+}
+
+func (l *Listener) OnStartFailure(ctx context.Context, err error) {
+	l.incError(err)
+}
+
+func (l *Listener) OnTransitionFailure(
+	ctx context.Context,
+	transitionID string,
+	st gowfnet.StateOpInterface,
+	err error,
+) {
+	l.incError(err)
+}
+
+func (l *Listener) HasStateListener() bool {
+	return true
+}
+
+func (l *Listener) GetStateListener() state.ListenerInterface {
+	return l
+}
+
+// OnFinish counts the finish and forgets places of the state.
+func (l *Listener) OnFinish(st state.OpInterface) {
+	l.metrics.Inc(MetricFinishes, nil)
+	l.Forget(st)
+}
+
+// OnError counts the last error of the state and forgets places of the state.
+func (l *Listener) OnError(st state.OpInterface) {
+	l.Forget(st)
+
+	errs := st.GetErrorStack().GetErrs()
+	if len(errs) == 0 {
+		return
+	}
+
+	l.metrics.Inc(MetricErrors, Labels{LabelCode: string(errs[len(errs)-1].GetCode())})
+}
+
+// Forget places of the state, time in them isn't observed.
+func (l *Listener) Forget(st state.OpInterface) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.markedAt, st)
+}
+
+func (l *Listener) BeforeMove(ctx context.Context, st state.OpInterface, from []string, to []string) error {
+	return nil
+}
+
+func (l *Listener) AfterMove(ctx context.Context, st state.OpInterface, from []string, to []string) {
+	l.leave(st, from)
+	l.mark(st, to)
+}
+
+// OnCancel observes time in cancelled places.
+func (l *Listener) OnCancel(ctx context.Context, st state.OpInterface, places []string) {
+	l.leave(st, places)
+}
+
+// OnRevert observes time in output places of the reverted transition, restored places are marked again.
+func (l *Listener) OnRevert(ctx context.Context, st state.OpInterface, entry state.HistoryEntry) {
+	l.leave(st, entry.To)
+	l.mark(st, entry.From)
+	l.mark(st, entry.Cancelled)
+}
+
+func (l *Listener) incError(err error) {
+	l.metrics.Inc(MetricErrors, Labels{LabelCode: string(state.BuildError(err).GetCode())})
+}
+
+func (l *Listener) leave(st state.OpInterface, places []string) {
+	now := l.clock.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	marked := l.markedAt[st]
+
+	for _, place := range places {
+		markedAt, ok := marked[place]
+		if !ok {
+			continue
+		}
+
+		delete(marked, place)
+		l.metrics.Observe(MetricPlaceDuration, Labels{LabelPlace: place}, now.Sub(markedAt).Seconds())
+	}
+
+	if len(marked) == 0 {
+		delete(l.markedAt, st)
+	}
+}
+
+func (l *Listener) mark(st state.OpInterface, places []string) {
+	if len(places) == 0 {
+		return
+	}
+
+	now := l.clock.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	marked, ok := l.markedAt[st]
+	if !ok {
+		marked = make(map[string]time.Time)
+		l.markedAt[st] = marked
+	}
+
+	for _, place := range places {
+		marked[place] = now
+	}
+}
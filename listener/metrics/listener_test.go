@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet"
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/clock"
+	"github.com/andrskom/gowfnet/state"
+)
+
+func newNet(listener gowfnet.ListenerInterface) *gowfnet.Net {
+	net := gowfnet.NewNet(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "inReview", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"submit":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"inReview"}},
+			"approve": {From: []cfg.StringID{"inReview"}, To: []cfg.StringID{"finish"}},
+		},
+	})
+	net.WithListener(listener)
+
+	return net
+}
+
+func TestListener_Net_MetricsAreCollected(t *testing.T) {
+	m := NewMemory()
+	c := clock.NewFake(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	net := newNet(New(m).WithClock(c))
+	ctx := context.Background()
+
+	st := state.NewState()
+	require.NoError(t, net.Start(ctx, st))
+	c.Add(time.Second)
+	require.NoError(t, net.Transit(ctx, st, "submit"))
+
+	err := net.Transit(ctx, st, "submit")
+	require.Error(t, err)
+
+	c.Add(2 * time.Second)
+	require.NoError(t, net.Transit(ctx, st, "approve"))
+
+	assert.Equal(t, float64(1), m.GetCounter(MetricStarts, nil))
+	assert.Equal(t, float64(2), m.GetCounter(MetricTransitions, Labels{LabelTransition: "submit"}))
+	assert.Equal(t, float64(1), m.GetCounter(MetricTransitions, Labels{LabelTransition: "approve"}))
+	assert.Equal(t, float64(1), m.GetCounter(MetricErrors, Labels{LabelCode: state.ErrCodeStateHasNotTokenInPlace}))
+	assert.Equal(t, float64(1), m.GetCounter(MetricFinishes, nil))
+
+	count, sum := m.GetSummary(MetricPlaceDuration, Labels{LabelPlace: "start"})
+	assert.Equal(t, uint64(1), count)
+	assert.Equal(t, float64(1), sum)
+
+	count, sum = m.GetSummary(MetricPlaceDuration, Labels{LabelPlace: "inReview"})
+	assert.Equal(t, uint64(1), count)
+	assert.Equal(t, float64(2), sum)
+}
+
+func TestListener_OnError_LastErrIsCounted(t *testing.T) {
+	m := NewMemory()
+	st := state.NewState()
+	st.WithListener(New(m))
+
+	st.AddError(errors.New("a"))
+	st.AddError(state.NewError(state.ErrCodeStateIsFinished, "b"))
+
+	assert.Equal(t, float64(1), m.GetCounter(MetricErrors, Labels{LabelCode: state.ErrCodeUnknown}))
+	assert.Equal(t, float64(1), m.GetCounter(MetricErrors, Labels{LabelCode: state.ErrCodeStateIsFinished}))
+}
+
+func TestListener_OnCancelAndOnRevert_TimeInPlacesIsObserved(t *testing.T) {
+	m := NewMemory()
+	c := clock.NewFake(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	l := New(m).WithClock(c)
+	st := state.NewState()
+	ctx := context.Background()
+
+	l.AfterMove(ctx, st, nil, []string{"a", "b"})
+	c.Add(time.Second)
+	l.OnCancel(ctx, st, []string{"b"})
+	l.OnRevert(ctx, st, state.HistoryEntry{From: []string{"c"}, To: []string{"a"}, Cancelled: []string{"b"}})
+	c.Add(time.Second)
+	l.AfterMove(ctx, st, []string{"b", "c"}, nil)
+
+	count, sum := m.GetSummary(MetricPlaceDuration, Labels{LabelPlace: "a"})
+	assert.Equal(t, uint64(1), count)
+	assert.Equal(t, float64(1), sum)
+
+	count, sum = m.GetSummary(MetricPlaceDuration, Labels{LabelPlace: "b"})
+	assert.Equal(t, uint64(2), count)
+	assert.Equal(t, float64(2), sum)
+
+	count, _ = m.GetSummary(MetricPlaceDuration, Labels{LabelPlace: "c"})
+	assert.Equal(t, uint64(1), count)
+}
+
+func TestListener_OnErrorAndOnFinish_StateIsForgotten(t *testing.T) {
+	m := NewMemory()
+	l := New(m)
+	ctx := context.Background()
+
+	failed := state.NewState()
+	failed.WithListener(l)
+	l.AfterMove(ctx, failed, nil, []string{"a"})
+	failed.AddError(errors.New("a"))
+
+	l.AfterMove(ctx, failed, []string{"a"}, nil)
+
+	count, _ := m.GetSummary(MetricPlaceDuration, Labels{LabelPlace: "a"})
+	assert.Zero(t, count)
+
+	finished := state.NewState()
+	l.AfterMove(ctx, finished, nil, []string{"b"})
+	l.OnFinish(finished)
+
+	assert.Empty(t, l.markedAt)
+}
+
+func TestListener_Forget_PlacesAreNotObserved(t *testing.T) {
+	m := NewMemory()
+	l := New(m)
+	st := state.NewState()
+	ctx := context.Background()
+
+	l.AfterMove(ctx, st, nil, []string{"a"})
+	l.Forget(st)
+	l.AfterMove(ctx, st, []string{"a"}, nil)
+
+	count, _ := m.GetSummary(MetricPlaceDuration, Labels{LabelPlace: "a"})
+	assert.Zero(t, count)
+	assert.Empty(t, l.markedAt)
+}
@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Labels of a series, keys are names of labels.
+type Labels map[string]string
+
+// Metrics is a minimal sink of metrics, implement it as an adapter of your metrics lib.
+type Metrics interface {
+	// Inc increments the counter.
+	Inc(name string, labels Labels)
+	// Observe adds the value to the summary.
+	Observe(name string, labels Labels, value float64)
+}
+
+// Kind of metric.
+type Kind string
+
+const (
+	KindCounter Kind = "counter"
+	KindSummary Kind = "summary"
+)
+
+// Series is a metric with labels.
+// Value is used for counters, Count and Sum are used for summaries.
+type Series struct {
+	Name   string
+	Kind   Kind
+	Labels Labels
+	Value  float64
+	Count  uint64
+	Sum    float64
+}
+
+// Memory keeps metrics in memory.
+type Memory struct {
+	mu     sync.Mutex
+	series map[string]*Series
+}
+
+// NewMemory init empty metrics.
+func NewMemory() *Memory {
+	return &Memory{series: make(map[string]*Series)}
+}
+
+func (m *Memory) Inc(name string, labels Labels) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.getSeries(name, KindCounter, labels).Value++
+}
+
+func (m *Memory) Observe(name string, labels Labels, value float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	series := m.getSeries(name, KindSummary, labels)
+	series.Count++
+	series.Sum += value
+}
+
+// GetCounter returns the value of the counter, 0 if it isn't incremented.
+func (m *Memory) GetCounter(name string, labels Labels) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if series, ok := m.series[buildSeriesKey(name, labels)]; ok {
+		return series.Value
+	}
+
+	return 0
+}
+
+// GetSummary returns the count and the sum of observed values.
+func (m *Memory) GetSummary(name string, labels Labels) (uint64, float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if series, ok := m.series[buildSeriesKey(name, labels)]; ok {
+		return series.Count, series.Sum
+	}
+
+	return 0, 0
+}
+
+// GetSeries returns copies of all series sorted by name and labels.
+func (m *Memory) GetSeries() []Series {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]string, 0, len(m.series))
+	for key := range m.series {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	res := make([]Series, 0, len(keys))
+
+	for _, key := range keys {
+		series := *m.series[key]
+		series.Labels = copyLabels(series.Labels)
+		res = append(res, series)
+	}
+
+	return res
+}
+
+func (m *Memory) getSeries(name string, kind Kind, labels Labels) *Series {
+	key := buildSeriesKey(name, labels)
+
+	series, ok := m.series[key]
+	if !ok {
+		series = &Series{Name: name, Kind: kind, Labels: copyLabels(labels)}
+		m.series[key] = series
+	}
+
+	return series
+}
+
+// buildSeriesKey builds the key which is sorted by name first, then by labels.
+func buildSeriesKey(name string, labels Labels) string {
+	var b strings.Builder
+
+	b.WriteString(name)
+
+	for _, key := range sortedLabelKeys(labels) {
+		b.WriteString("\x00")
+		b.WriteString(key)
+		b.WriteString("\x01")
+		b.WriteString(labels[key])
+	}
+
+	return b.String()
+}
+
+func sortedLabelKeys(labels Labels) []string {
+	res := make([]string, 0, len(labels))
+	for key := range labels {
+		res = append(res, key)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+func copyLabels(labels Labels) Labels {
+	res := make(Labels, len(labels))
+	for key, value := range labels {
+		res[key] = value
+	}
+
+	return res
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemory_IncAndObserve_SeriesAreSorted(t *testing.T) {
+	m := NewMemory()
+	m.Inc("b", Labels{"x": "2"})
+	m.Inc("b", Labels{"x": "1"})
+	m.Inc("b", Labels{"x": "1"})
+	m.Observe("a", nil, 1.5)
+	m.Observe("a", nil, 2)
+
+	assert.Equal(
+		t,
+		[]Series{
+			{Name: "a", Kind: KindSummary, Labels: Labels{}, Count: 2, Sum: 3.5},
+			{Name: "b", Kind: KindCounter, Labels: Labels{"x": "1"}, Value: 2},
+			{Name: "b", Kind: KindCounter, Labels: Labels{"x": "2"}, Value: 1},
+		},
+		m.GetSeries(),
+	)
+}
+
+func TestMemory_Get(t *testing.T) {
+	m := NewMemory()
+	labels := Labels{"x": "1"}
+	m.Inc("c", labels)
+	m.Observe("s", labels, 2)
+
+	labels["x"] = "2"
+
+	assert.Equal(t, float64(1), m.GetCounter("c", Labels{"x": "1"}))
+	assert.Equal(t, float64(0), m.GetCounter("c", Labels{"x": "2"}))
+
+	count, sum := m.GetSummary("s", Labels{"x": "1"})
+	assert.Equal(t, uint64(1), count)
+	assert.Equal(t, float64(2), sum)
+
+	count, sum = m.GetSummary("unknown", nil)
+	assert.Equal(t, uint64(0), count)
+	assert.Equal(t, float64(0), sum)
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ContentTypePrometheus is the content type of Prometheus text exposition format.
+const ContentTypePrometheus = "text/plain; version=0.0.4; charset=utf-8"
+
+// Prometheus exposes in-memory metrics in Prometheus text exposition format.
+// It is http.Handler, so it can be mounted as an endpoint for scraping.
+type Prometheus struct {
+	memory *Memory
+}
+
+// NewPrometheus init adapter for the metrics.
+func NewPrometheus(memory *Memory) *Prometheus {
+	return &Prometheus{memory: memory}
+}
+
+// WriteTo writes all series in text exposition format, summaries are written as _sum and _count.
+func (p *Prometheus) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+
+	prevName := ""
+
+	for _, series := range p.memory.GetSeries() {
+		if series.Name != prevName {
+			fmt.Fprintf(&buf, "# TYPE %s %s\n", series.Name, series.Kind)
+			prevName = series.Name
+		}
+
+		labels := formatLabels(series.Labels)
+
+		switch series.Kind {
+		case KindCounter:
+			fmt.Fprintf(&buf, "%s%s %s\n", series.Name, labels, formatValue(series.Value))
+		case KindSummary:
+			fmt.Fprintf(&buf, "%s_sum%s %s\n", series.Name, labels, formatValue(series.Sum))
+			fmt.Fprintf(&buf, "%s_count%s %d\n", series.Name, labels, series.Count)
+		}
+	}
+
+	return buf.WriteTo(w)
+}
+
+func (p *Prometheus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", ContentTypePrometheus)
+
+	_, _ = p.WriteTo(w)
+}
+
+func formatLabels(labels Labels) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	parts := make([]string, 0, len(labels))
+
+	for _, key := range sortedLabelKeys(labels) {
+		parts = append(parts, key+`="`+escapeLabelValue(labels[key])+`"`)
+	}
+
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
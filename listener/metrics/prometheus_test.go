@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheus_WriteTo_TextFormat(t *testing.T) {
+	m := NewMemory()
+	m.Inc("requests_total", Labels{"path": `/a"b\`, "code": "200"})
+	m.Inc("requests_total", nil)
+	m.Observe("latency_seconds", Labels{"path": "/"}, 0.25)
+	m.Observe("latency_seconds", Labels{"path": "/"}, 0.5)
+
+	var buf bytes.Buffer
+
+	n, err := NewPrometheus(m).WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.Equal(
+		t,
+		"# TYPE latency_seconds summary\n"+
+			"latency_seconds_sum{path=\"/\"} 0.75\n"+
+			"latency_seconds_count{path=\"/\"} 2\n"+
+			"# TYPE requests_total counter\n"+
+			"requests_total 1\n"+
+			"requests_total{code=\"200\",path=\"/a\\\"b\\\\\"} 1\n",
+		buf.String(),
+	)
+}
+
+func TestPrometheus_ServeHTTP(t *testing.T) {
+	m := NewMemory()
+	m.Inc("a", nil)
+
+	rec := httptest.NewRecorder()
+	NewPrometheus(m).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, ContentTypePrometheus, rec.Header().Get("Content-Type"))
+	assert.Equal(t, "# TYPE a counter\na 1\n", rec.Body.String())
+}
@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+)
+
+// RecordedSpan is a span of Recorder.
+type RecordedSpan struct {
+	Name       string
+	Attributes map[string]interface{}
+	Errors     []error
+	Ended      bool
+	mu         *sync.Mutex
+}
+
+func (s *RecordedSpan) SetAttribute(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Attributes[key] = value
+}
+
+func (s *RecordedSpan) RecordError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Errors = append(s.Errors, err)
+}
+
+func (s *RecordedSpan) End() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Ended = true
+}
+
+// Recorder is an in-memory tracer, use it for tests and debugging without an exporter.
+type Recorder struct {
+	mu    sync.Mutex
+	spans []*RecordedSpan
+}
+
+// NewRecorder init empty recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{spans: make([]*RecordedSpan, 0)}
+}
+
+func (r *Recorder) Start(ctx context.Context, name string) Span {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	span := &RecordedSpan{
+		Name:       name,
+		Attributes: make(map[string]interface{}),
+		Errors:     make([]error, 0),
+		mu:         &r.mu,
+	}
+
+	r.spans = append(r.spans, span)
+
+	return span
+}
+
+// GetSpans returns copies of recorded spans in order of starting.
+func (r *Recorder) GetSpans() []RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res := make([]RecordedSpan, 0, len(r.spans))
+
+	for _, span := range r.spans {
+		attributes := make(map[string]interface{}, len(span.Attributes))
+		for key, value := range span.Attributes {
+			attributes[key] = value
+		}
+
+		res = append(res, RecordedSpan{
+			Name:       span.Name,
+			Attributes: attributes,
+			Errors:     append([]error{}, span.Errors...),
+			Ended:      span.Ended,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,241 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"github.com/andrskom/gowfnet"
+	"github.com/andrskom/gowfnet/state"
+)
+
+// Names of spans.
+const (
+	SpanStart      = "gowfnet.start"
+	SpanTransition = "gowfnet.transition"
+)
+
+// Keys of attributes of spans.
+const (
+	AttrTransitionID = "gowfnet.transition.id"
+	AttrFrom         = "gowfnet.move.from"
+	AttrTo           = "gowfnet.move.to"
+	AttrFinished     = "gowfnet.state.finished"
+	AttrErrorCode    = "gowfnet.error.code"
+	AttrRemoved      = "gowfnet.subprocess.removed"
+)
+
+// Tracer starts spans, implement it as an adapter of your tracing lib, e.g. OpenTelemetry.
+type Tracer interface {
+	Start(ctx context.Context, name string) Span
+}
+
+// Span of the tracer.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// Listener opens a span for each Start and transition of the net.
+//
+// Spans are opened in BeforeStart and BeforeTransition and are ended in AfterStart, AfterTransition
+// or on the failure of the start or the transition, see gowfnet.FailureListenerInterface.
+// Spans of subprocess transitions are ended on removal of the running subprocess too,
+// see gowfnet.SubprocessListenerInterface.
+// Places of moves are set to the span as attributes, the listener is the state listener too.
+//
+// Spans of starts are found by ctx, Net.Start passes its own ctx for each start.
+type Listener struct {
+	tracer      Tracer
+	mu          sync.Mutex
+	starts      map[context.Context]Span
+	transitions map[context.Context]*transitionSpan
+	byState     map[stateKey]*transitionSpan
+}
+
+type stateKey struct {
+	state        gowfnet.StateOpInterface
+	transitionID string
+}
+
+type transitionSpan struct {
+	span Span
+	ctx  context.Context
+	key  stateKey
+}
+
+// New init listener with the tracer.
+func New(tracer Tracer) *Listener {
+	return &Listener{
+		tracer:      tracer,
+		starts:      make(map[context.Context]Span),
+		transitions: make(map[context.Context]*transitionSpan),
+		byState:     make(map[stateKey]*transitionSpan),
+	}
+}
+
+func (l *Listener) BeforeStart(ctx context.Context) error {
+	span := l.tracer.Start(ctx, SpanStart)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.starts[ctx] = span
+
+	return nil
+}
+
+func (l *Listener) AfterStart(ctx context.Context) {
+	span, ok := l.popStart(ctx)
+	if !ok {
+		return
+	}
+
+	span.End()
+}
+
+// OnStartFailure records the err to the span of start and ends it.
+func (l *Listener) OnStartFailure(ctx context.Context, err error) {
+	span, ok := l.popStart(ctx)
+	if !ok {
+		return
+	}
+
+	recordError(span, err)
+	span.End()
+}
+
+func (l *Listener) BeforeTransition(ctx context.Context, transitionID string, st gowfnet.StateOpInterface) error {
+	span := l.tracer.Start(ctx, SpanTransition)
+	span.SetAttribute(AttrTransitionID, transitionID)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	tr := &transitionSpan{span: span, ctx: ctx, key: stateKey{state: st, transitionID: transitionID}}
+	l.transitions[ctx] = tr
+	l.byState[tr.key] = tr
+
+	return nil
+}
+
+func (l *Listener) AfterTransition(ctx context.Context, transitionID string, st gowfnet.StateOpInterface) {
+	tr, ok := l.popTransition(ctx, transitionID, st)
+	if !ok {
+		return
+	}
+
+	tr.span.SetAttribute(AttrFinished, st.IsFinished())
+	tr.span.End()
+}
+
+// OnSubprocessRemoval ends the span of the subprocess transition which isn't completed.
+func (l *Listener) OnSubprocessRemoval(ctx context.Context, transitionID string, st gowfnet.StateOpInterface) {
+	tr, ok := l.popTransition(ctx, transitionID, st)
+	if !ok {
+		return
+	}
+
+	tr.span.SetAttribute(AttrRemoved, true)
+	tr.span.End()
+}
+
+// OnTransitionFailure records the err to the span of the transition and ends it.
+func (l *Listener) OnTransitionFailure(
+	ctx context.Context,
+	transitionID string,
+	st gowfnet.StateOpInterface,
+	err error,
+) {
+	tr, ok := l.popTransition(ctx, transitionID, st)
+	if !ok {
+		return
+	}
+
+	recordError(tr.span, err)
+	tr.span.End()
+}
+
+func (l *Listener) HasStateListener() bool {
+	return true
+}
+
+func (l *Listener) GetStateListener() state.ListenerInterface {
+	return l
+}
+
+func (l *Listener) OnFinish(st state.OpInterface) {}
+
+func (l *Listener) OnError(st state.OpInterface) {}
+
+func (l *Listener) BeforeMove(ctx context.Context, st state.OpInterface, from []string, to []string) error {
+	return nil
+}
+
+// AfterMove sets places of the move to the span of the transition or start with the same ctx.
+func (l *Listener) AfterMove(ctx context.Context, st state.OpInterface, from []string, to []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var span Span
+
+	if tr, ok := l.transitions[ctx]; ok {
+		span = tr.span
+	} else if startSpan, ok := l.starts[ctx]; ok {
+		span = startSpan
+	}
+
+	if span == nil {
+		return
+	}
+
+	span.SetAttribute(AttrFrom, append([]string{}, from...))
+	span.SetAttribute(AttrTo, append([]string{}, to...))
+}
+
+func (l *Listener) popStart(ctx context.Context) (Span, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	span, ok := l.starts[ctx]
+	if !ok {
+		return nil, false
+	}
+
+	delete(l.starts, ctx)
+
+	return span, true
+}
+
+// popTransition finds the span by ctx or, for subprocesses which are completed with another ctx,
+// by the state and the transition id.
+func (l *Listener) popTransition(
+	ctx context.Context,
+	transitionID string,
+	st gowfnet.StateOpInterface,
+) (*transitionSpan, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	tr, ok := l.transitions[ctx]
+	if !ok || tr.key.transitionID != transitionID {
+		tr, ok = l.byState[stateKey{state: st, transitionID: transitionID}]
+	}
+
+	if !ok {
+		return nil, false
+	}
+
+	delete(l.byState, tr.key)
+
+	if l.transitions[tr.ctx] == tr {
+		delete(l.transitions, tr.ctx)
+	}
+
+	return tr, true
+}
+
+func recordError(span Span, err error) {
+	span.SetAttribute(AttrErrorCode, string(state.BuildError(err).GetCode()))
+	span.RecordError(err)
+}
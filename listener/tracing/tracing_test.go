@@ -0,0 +1,333 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet"
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/listener/composite"
+	"github.com/andrskom/gowfnet/state"
+)
+
+type failingStateListener struct {
+	*state.StubListener
+}
+
+func (l *failingStateListener) BeforeMove(ctx context.Context, st state.OpInterface, from []string, to []string) error {
+	if _, ok := state.GetTransitionID(ctx); ok {
+		return errors.New("move failed")
+	}
+
+	return nil
+}
+
+type startFailingStateListener struct {
+	*state.StubListener
+	err error
+}
+
+func (l *startFailingStateListener) BeforeMove(
+	ctx context.Context,
+	st state.OpInterface,
+	from []string,
+	to []string,
+) error {
+	return l.err
+}
+
+type startFailingListener struct {
+	*gowfnet.StubListener
+	err error
+}
+
+func (l *startFailingListener) HasStateListener() bool {
+	return true
+}
+
+func (l *startFailingListener) GetStateListener() state.ListenerInterface {
+	return &startFailingStateListener{StubListener: state.NewStubListener(), err: l.err}
+}
+
+type failingListener struct {
+	*Listener
+}
+
+func (l *failingListener) GetStateListener() state.ListenerInterface {
+	return &failingStateListener{StubListener: state.NewStubListener()}
+}
+
+func newNet() *gowfnet.Net {
+	return gowfnet.NewNet(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "inReview", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"submit":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"inReview"}},
+			"approve": {From: []cfg.StringID{"inReview"}, To: []cfg.StringID{"finish"}},
+		},
+	})
+}
+
+func TestListener_StartAndTransit_SpansAreRecorded(t *testing.T) {
+	recorder := NewRecorder()
+	net := newNet()
+	net.WithListener(New(recorder))
+
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+	require.NoError(t, net.Transit(context.Background(), st, "submit"))
+	require.NoError(t, net.Transit(context.Background(), st, "approve"))
+
+	assert.Equal(
+		t,
+		[]RecordedSpan{
+			{
+				Name:       SpanStart,
+				Attributes: map[string]interface{}{AttrFrom: []string{}, AttrTo: []string{"start"}},
+				Errors:     []error{},
+				Ended:      true,
+			},
+			{
+				Name: SpanTransition,
+				Attributes: map[string]interface{}{
+					AttrTransitionID: "submit",
+					AttrFrom:         []string{"start"},
+					AttrTo:           []string{"inReview"},
+					AttrFinished:     false,
+				},
+				Errors: []error{},
+				Ended:  true,
+			},
+			{
+				Name: SpanTransition,
+				Attributes: map[string]interface{}{
+					AttrTransitionID: "approve",
+					AttrFrom:         []string{"inReview"},
+					AttrTo:           []string{"finish"},
+					AttrFinished:     true,
+				},
+				Errors: []error{},
+				Ended:  true,
+			},
+		},
+		recorder.GetSpans(),
+	)
+}
+
+func TestListener_TransitErr_ErrIsRecorded(t *testing.T) {
+	recorder := NewRecorder()
+	net := newNet()
+	net.WithListener(New(recorder))
+
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+
+	err := net.Transit(context.Background(), st, "approve")
+	require.Error(t, err)
+
+	spans := recorder.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(
+		t,
+		RecordedSpan{
+			Name: SpanTransition,
+			Attributes: map[string]interface{}{
+				AttrTransitionID: "approve",
+				AttrErrorCode:    state.ErrCodeStateHasNotTokenInPlace,
+			},
+			Errors: []error{err},
+			Ended:  true,
+		},
+		spans[1],
+	)
+}
+
+func TestListener_StateListenerErr_ErrIsRecorded(t *testing.T) {
+	recorder := NewRecorder()
+	net := newNet()
+	net.WithListener(&failingListener{Listener: New(recorder)})
+
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+
+	err := net.Transit(context.Background(), st, "submit")
+	require.EqualError(t, err, "move failed")
+
+	spans := recorder.GetSpans()
+	require.Len(t, spans, 2)
+	assert.True(t, spans[1].Ended)
+	assert.Equal(t, []error{err}, spans[1].Errors)
+	assert.Equal(t, state.ErrCodeUnknown, spans[1].Attributes[AttrErrorCode])
+}
+
+func TestListener_StartErr_SpanIsEndedWithErr(t *testing.T) {
+	eErr := errors.New("start failed")
+
+	for name, newListener := range map[string]func(l *Listener) gowfnet.ListenerInterface{
+		"failingFirst": func(l *Listener) gowfnet.ListenerInterface {
+			return composite.New(&startFailingListener{StubListener: gowfnet.NewStubListener(), err: eErr}, l)
+		},
+		"failingLast": func(l *Listener) gowfnet.ListenerInterface {
+			return composite.New(l, &startFailingListener{StubListener: gowfnet.NewStubListener(), err: eErr})
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			recorder := NewRecorder()
+			l := New(recorder)
+			net := newNet()
+			net.WithListener(newListener(l))
+
+			err := net.Start(context.Background(), state.NewState())
+			require.Error(t, err)
+
+			spans := recorder.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, SpanStart, spans[0].Name)
+			assert.True(t, spans[0].Ended)
+			assert.Equal(t, []error{err}, spans[0].Errors)
+			assert.Empty(t, l.starts)
+		})
+	}
+}
+
+type beforeStartFailingListener struct {
+	*gowfnet.StubListener
+	err error
+}
+
+func (l *beforeStartFailingListener) BeforeStart(ctx context.Context) error {
+	return l.err
+}
+
+func TestListener_BeforeStartErrOfComposite_SpanIsEndedWithErr(t *testing.T) {
+	eErr := errors.New("start failed")
+	recorder := NewRecorder()
+	l := New(recorder)
+	net := newNet()
+	net.WithListener(composite.New(
+		l,
+		&beforeStartFailingListener{StubListener: gowfnet.NewStubListener(), err: eErr},
+	).WithPolicy(composite.PolicyContinue))
+
+	assert.Same(t, eErr, net.Start(context.Background(), state.NewState()))
+
+	spans := recorder.GetSpans()
+	require.Len(t, spans, 1)
+	assert.True(t, spans[0].Ended)
+	assert.Equal(t, []error{eErr}, spans[0].Errors)
+	assert.Empty(t, l.starts)
+}
+
+func TestListener_ConcurrentStartsWithTheSameCtx_SpansAreEnded(t *testing.T) {
+	recorder := NewRecorder()
+	l := New(recorder)
+	net := newNet()
+	net.WithListener(l)
+
+	ctx := context.Background()
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, net.Start(ctx, state.NewState()))
+		}()
+	}
+
+	wg.Wait()
+
+	spans := recorder.GetSpans()
+	require.Len(t, spans, 10)
+
+	for _, span := range spans {
+		assert.True(t, span.Ended)
+		assert.Equal(t, []string{"start"}, span.Attributes[AttrTo])
+	}
+
+	assert.Empty(t, l.starts)
+}
+
+func TestListener_CancelledSubprocess_SpanIsEnded(t *testing.T) {
+	registry := cfg.NewRegistry()
+	require.NoError(t, registry.AddWithName("review", cfg.Minimal{
+		Start:       "start",
+		Finish:      "finish",
+		Places:      []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{"approve": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}}},
+	}))
+
+	net := gowfnet.NewNet(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "payment", "shipping", "cancelled", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"order":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"payment", "shipping"}},
+			"ship":   {From: []cfg.StringID{"shipping"}, To: []cfg.StringID{"finish"}, Subprocess: "review"},
+			"cancel": {From: []cfg.StringID{"payment"}, To: []cfg.StringID{"cancelled"}, Cancel: []cfg.StringID{"shipping"}},
+		},
+	})
+	net.WithRegistry(registry)
+
+	recorder := NewRecorder()
+	l := New(recorder)
+	net.WithListener(composite.New(l))
+
+	ctx := context.Background()
+	st := state.NewState()
+	require.NoError(t, net.Start(ctx, st))
+	require.NoError(t, net.Transit(ctx, st, "order"))
+	require.NoError(t, net.Transit(ctx, st, "ship"))
+	require.NoError(t, net.Transit(ctx, st, "cancel"))
+
+	removed := 0
+
+	for _, span := range recorder.GetSpans() {
+		assert.True(t, span.Ended, span.Name)
+
+		if span.Attributes[AttrTransitionID] == "ship" {
+			assert.Equal(t, true, span.Attributes[AttrRemoved])
+			removed++
+		}
+	}
+
+	assert.Equal(t, 1, removed)
+
+	assert.Empty(t, l.transitions)
+	assert.Empty(t, l.byState)
+}
+
+func TestListener_AfterTransition_WithoutSpan_Ignored(t *testing.T) {
+	recorder := NewRecorder()
+	l := New(recorder)
+
+	l.AfterTransition(context.Background(), "a", state.NewState())
+	l.OnTransitionFailure(context.Background(), "a", state.NewState(), errors.New("a"))
+	l.AfterStart(context.Background())
+	l.OnStartFailure(context.Background(), errors.New("a"))
+
+	assert.Empty(t, recorder.GetSpans())
+}
+
+func TestListener_AfterTransition_AnotherCtx_SpanIsFoundByState(t *testing.T) {
+	recorder := NewRecorder()
+	l := New(recorder)
+	st := state.NewState()
+
+	require.NoError(t, l.BeforeTransition(context.Background(), "sub", st))
+	l.AfterTransition(context.WithValue(context.Background(), struct{}{}, 1), "sub", st)
+
+	spans := recorder.GetSpans()
+	require.Len(t, spans, 1)
+	assert.True(t, spans[0].Ended)
+	assert.Empty(t, l.transitions)
+	assert.Empty(t, l.byState)
+}
@@ -8,6 +8,7 @@ import (
 	cfg "github.com/andrskom/gowfnet/cfg"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
+	time "time"
 )
 
 // MockIDGetter is a mock of IDGetter interface
@@ -98,6 +99,317 @@ func (mr *MockTransitionInterfaceMockRecorder) GetTo() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTo", reflect.TypeOf((*MockTransitionInterface)(nil).GetTo))
 }
 
+// MockTimedTransitionInterface is a mock of TimedTransitionInterface interface
+type MockTimedTransitionInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimedTransitionInterfaceMockRecorder
+}
+
+// MockTimedTransitionInterfaceMockRecorder is the mock recorder for MockTimedTransitionInterface
+type MockTimedTransitionInterfaceMockRecorder struct {
+	mock *MockTimedTransitionInterface
+}
+
+// NewMockTimedTransitionInterface creates a new mock instance
+func NewMockTimedTransitionInterface(ctrl *gomock.Controller) *MockTimedTransitionInterface {
+	mock := &MockTimedTransitionInterface{ctrl: ctrl}
+	mock.recorder = &MockTimedTransitionInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTimedTransitionInterface) EXPECT() *MockTimedTransitionInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetFrom mocks base method
+func (m *MockTimedTransitionInterface) GetFrom() []cfg.IDGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFrom")
+	ret0, _ := ret[0].([]cfg.IDGetter)
+	return ret0
+}
+
+// GetFrom indicates an expected call of GetFrom
+func (mr *MockTimedTransitionInterfaceMockRecorder) GetFrom() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFrom", reflect.TypeOf((*MockTimedTransitionInterface)(nil).GetFrom))
+}
+
+// GetTo mocks base method
+func (m *MockTimedTransitionInterface) GetTo() []cfg.IDGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTo")
+	ret0, _ := ret[0].([]cfg.IDGetter)
+	return ret0
+}
+
+// GetTo indicates an expected call of GetTo
+func (mr *MockTimedTransitionInterfaceMockRecorder) GetTo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTo", reflect.TypeOf((*MockTimedTransitionInterface)(nil).GetTo))
+}
+
+// GetTimer mocks base method
+func (m *MockTimedTransitionInterface) GetTimer() cfg.TimerInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimer")
+	ret0, _ := ret[0].(cfg.TimerInterface)
+	return ret0
+}
+
+// GetTimer indicates an expected call of GetTimer
+func (mr *MockTimedTransitionInterfaceMockRecorder) GetTimer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimer", reflect.TypeOf((*MockTimedTransitionInterface)(nil).GetTimer))
+}
+
+// MockSubprocessTransitionInterface is a mock of SubprocessTransitionInterface interface
+type MockSubprocessTransitionInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubprocessTransitionInterfaceMockRecorder
+}
+
+// MockSubprocessTransitionInterfaceMockRecorder is the mock recorder for MockSubprocessTransitionInterface
+type MockSubprocessTransitionInterfaceMockRecorder struct {
+	mock *MockSubprocessTransitionInterface
+}
+
+// NewMockSubprocessTransitionInterface creates a new mock instance
+func NewMockSubprocessTransitionInterface(ctrl *gomock.Controller) *MockSubprocessTransitionInterface {
+	mock := &MockSubprocessTransitionInterface{ctrl: ctrl}
+	mock.recorder = &MockSubprocessTransitionInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSubprocessTransitionInterface) EXPECT() *MockSubprocessTransitionInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetFrom mocks base method
+func (m *MockSubprocessTransitionInterface) GetFrom() []cfg.IDGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFrom")
+	ret0, _ := ret[0].([]cfg.IDGetter)
+	return ret0
+}
+
+// GetFrom indicates an expected call of GetFrom
+func (mr *MockSubprocessTransitionInterfaceMockRecorder) GetFrom() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFrom", reflect.TypeOf((*MockSubprocessTransitionInterface)(nil).GetFrom))
+}
+
+// GetTo mocks base method
+func (m *MockSubprocessTransitionInterface) GetTo() []cfg.IDGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTo")
+	ret0, _ := ret[0].([]cfg.IDGetter)
+	return ret0
+}
+
+// GetTo indicates an expected call of GetTo
+func (mr *MockSubprocessTransitionInterfaceMockRecorder) GetTo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTo", reflect.TypeOf((*MockSubprocessTransitionInterface)(nil).GetTo))
+}
+
+// GetSubprocess mocks base method
+func (m *MockSubprocessTransitionInterface) GetSubprocess() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubprocess")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetSubprocess indicates an expected call of GetSubprocess
+func (mr *MockSubprocessTransitionInterfaceMockRecorder) GetSubprocess() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubprocess", reflect.TypeOf((*MockSubprocessTransitionInterface)(nil).GetSubprocess))
+}
+
+// MockCancellingTransitionInterface is a mock of CancellingTransitionInterface interface
+type MockCancellingTransitionInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCancellingTransitionInterfaceMockRecorder
+}
+
+// MockCancellingTransitionInterfaceMockRecorder is the mock recorder for MockCancellingTransitionInterface
+type MockCancellingTransitionInterfaceMockRecorder struct {
+	mock *MockCancellingTransitionInterface
+}
+
+// NewMockCancellingTransitionInterface creates a new mock instance
+func NewMockCancellingTransitionInterface(ctrl *gomock.Controller) *MockCancellingTransitionInterface {
+	mock := &MockCancellingTransitionInterface{ctrl: ctrl}
+	mock.recorder = &MockCancellingTransitionInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCancellingTransitionInterface) EXPECT() *MockCancellingTransitionInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetFrom mocks base method
+func (m *MockCancellingTransitionInterface) GetFrom() []cfg.IDGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFrom")
+	ret0, _ := ret[0].([]cfg.IDGetter)
+	return ret0
+}
+
+// GetFrom indicates an expected call of GetFrom
+func (mr *MockCancellingTransitionInterfaceMockRecorder) GetFrom() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFrom", reflect.TypeOf((*MockCancellingTransitionInterface)(nil).GetFrom))
+}
+
+// GetTo mocks base method
+func (m *MockCancellingTransitionInterface) GetTo() []cfg.IDGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTo")
+	ret0, _ := ret[0].([]cfg.IDGetter)
+	return ret0
+}
+
+// GetTo indicates an expected call of GetTo
+func (mr *MockCancellingTransitionInterfaceMockRecorder) GetTo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTo", reflect.TypeOf((*MockCancellingTransitionInterface)(nil).GetTo))
+}
+
+// GetCancel mocks base method
+func (m *MockCancellingTransitionInterface) GetCancel() []cfg.IDGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCancel")
+	ret0, _ := ret[0].([]cfg.IDGetter)
+	return ret0
+}
+
+// GetCancel indicates an expected call of GetCancel
+func (mr *MockCancellingTransitionInterfaceMockRecorder) GetCancel() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCancel", reflect.TypeOf((*MockCancellingTransitionInterface)(nil).GetCancel))
+}
+
+// MockReversibleTransitionInterface is a mock of ReversibleTransitionInterface interface
+type MockReversibleTransitionInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockReversibleTransitionInterfaceMockRecorder
+}
+
+// MockReversibleTransitionInterfaceMockRecorder is the mock recorder for MockReversibleTransitionInterface
+type MockReversibleTransitionInterfaceMockRecorder struct {
+	mock *MockReversibleTransitionInterface
+}
+
+// NewMockReversibleTransitionInterface creates a new mock instance
+func NewMockReversibleTransitionInterface(ctrl *gomock.Controller) *MockReversibleTransitionInterface {
+	mock := &MockReversibleTransitionInterface{ctrl: ctrl}
+	mock.recorder = &MockReversibleTransitionInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockReversibleTransitionInterface) EXPECT() *MockReversibleTransitionInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetFrom mocks base method
+func (m *MockReversibleTransitionInterface) GetFrom() []cfg.IDGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFrom")
+	ret0, _ := ret[0].([]cfg.IDGetter)
+	return ret0
+}
+
+// GetFrom indicates an expected call of GetFrom
+func (mr *MockReversibleTransitionInterfaceMockRecorder) GetFrom() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFrom", reflect.TypeOf((*MockReversibleTransitionInterface)(nil).GetFrom))
+}
+
+// GetTo mocks base method
+func (m *MockReversibleTransitionInterface) GetTo() []cfg.IDGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTo")
+	ret0, _ := ret[0].([]cfg.IDGetter)
+	return ret0
+}
+
+// GetTo indicates an expected call of GetTo
+func (mr *MockReversibleTransitionInterfaceMockRecorder) GetTo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTo", reflect.TypeOf((*MockReversibleTransitionInterface)(nil).GetTo))
+}
+
+// IsReversible mocks base method
+func (m *MockReversibleTransitionInterface) IsReversible() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsReversible")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsReversible indicates an expected call of IsReversible
+func (mr *MockReversibleTransitionInterfaceMockRecorder) IsReversible() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsReversible", reflect.TypeOf((*MockReversibleTransitionInterface)(nil).IsReversible))
+}
+
+// MockTimerInterface is a mock of TimerInterface interface
+type MockTimerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTimerInterfaceMockRecorder
+}
+
+// MockTimerInterfaceMockRecorder is the mock recorder for MockTimerInterface
+type MockTimerInterfaceMockRecorder struct {
+	mock *MockTimerInterface
+}
+
+// NewMockTimerInterface creates a new mock instance
+func NewMockTimerInterface(ctrl *gomock.Controller) *MockTimerInterface {
+	mock := &MockTimerInterface{ctrl: ctrl}
+	mock.recorder = &MockTimerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTimerInterface) EXPECT() *MockTimerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetType mocks base method
+func (m *MockTimerInterface) GetType() cfg.TimerType {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetType")
+	ret0, _ := ret[0].(cfg.TimerType)
+	return ret0
+}
+
+// GetType indicates an expected call of GetType
+func (mr *MockTimerInterfaceMockRecorder) GetType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetType", reflect.TypeOf((*MockTimerInterface)(nil).GetType))
+}
+
+// GetDuration mocks base method
+func (m *MockTimerInterface) GetDuration() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuration")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetDuration indicates an expected call of GetDuration
+func (mr *MockTimerInterfaceMockRecorder) GetDuration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuration", reflect.TypeOf((*MockTimerInterface)(nil).GetDuration))
+}
+
 // MockTransitionRegistryInterface is a mock of TransitionRegistryInterface interface
 type MockTransitionRegistryInterface struct {
 	ctrl     *gomock.Controller
@@ -228,3 +540,96 @@ func (mr *MockInterfaceMockRecorder) GetTransitions() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransitions", reflect.TypeOf((*MockInterface)(nil).GetTransitions))
 }
+
+// MockOutcomesInterface is a mock of OutcomesInterface interface
+type MockOutcomesInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutcomesInterfaceMockRecorder
+}
+
+// MockOutcomesInterfaceMockRecorder is the mock recorder for MockOutcomesInterface
+type MockOutcomesInterfaceMockRecorder struct {
+	mock *MockOutcomesInterface
+}
+
+// NewMockOutcomesInterface creates a new mock instance
+func NewMockOutcomesInterface(ctrl *gomock.Controller) *MockOutcomesInterface {
+	mock := &MockOutcomesInterface{ctrl: ctrl}
+	mock.recorder = &MockOutcomesInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockOutcomesInterface) EXPECT() *MockOutcomesInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetStart mocks base method
+func (m *MockOutcomesInterface) GetStart() cfg.IDGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStart")
+	ret0, _ := ret[0].(cfg.IDGetter)
+	return ret0
+}
+
+// GetStart indicates an expected call of GetStart
+func (mr *MockOutcomesInterfaceMockRecorder) GetStart() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStart", reflect.TypeOf((*MockOutcomesInterface)(nil).GetStart))
+}
+
+// GetFinish mocks base method
+func (m *MockOutcomesInterface) GetFinish() cfg.IDGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFinish")
+	ret0, _ := ret[0].(cfg.IDGetter)
+	return ret0
+}
+
+// GetFinish indicates an expected call of GetFinish
+func (mr *MockOutcomesInterfaceMockRecorder) GetFinish() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFinish", reflect.TypeOf((*MockOutcomesInterface)(nil).GetFinish))
+}
+
+// GetPlaces mocks base method
+func (m *MockOutcomesInterface) GetPlaces() []cfg.IDGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaces")
+	ret0, _ := ret[0].([]cfg.IDGetter)
+	return ret0
+}
+
+// GetPlaces indicates an expected call of GetPlaces
+func (mr *MockOutcomesInterfaceMockRecorder) GetPlaces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaces", reflect.TypeOf((*MockOutcomesInterface)(nil).GetPlaces))
+}
+
+// GetTransitions mocks base method
+func (m *MockOutcomesInterface) GetTransitions() cfg.TransitionRegistryInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransitions")
+	ret0, _ := ret[0].(cfg.TransitionRegistryInterface)
+	return ret0
+}
+
+// GetTransitions indicates an expected call of GetTransitions
+func (mr *MockOutcomesInterfaceMockRecorder) GetTransitions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransitions", reflect.TypeOf((*MockOutcomesInterface)(nil).GetTransitions))
+}
+
+// GetOutcomes mocks base method
+func (m *MockOutcomesInterface) GetOutcomes() map[string]cfg.IDGetter {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutcomes")
+	ret0, _ := ret[0].(map[string]cfg.IDGetter)
+	return ret0
+}
+
+// GetOutcomes indicates an expected call of GetOutcomes
+func (mr *MockOutcomesInterfaceMockRecorder) GetOutcomes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutcomes", reflect.TypeOf((*MockOutcomesInterface)(nil).GetOutcomes))
+}
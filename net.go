@@ -2,13 +2,19 @@ package gowfnet
 
 import (
 	"context"
+	"sort"
 
 	"github.com/andrskom/gowfnet/cfg"
 	"github.com/andrskom/gowfnet/state"
 )
 
-// nolint:gochecknoglobals
-var ctxSubject = &struct{}{}
+// ctxKey is a type of keys of ctx values of the pkg.
+type ctxKey int
+
+const (
+	ctxSubject ctxKey = iota
+	ctxStart
+)
 
 func SetSubject(ctx context.Context, subj interface{}) context.Context {
 	return context.WithValue(ctx, ctxSubject, subj)
@@ -43,6 +49,27 @@ type StateInterface interface {
 	MoveTokensFromPlacesToPlaces(ctx context.Context, from []string, to []string) error
 }
 
+// NestedStateInterface is a state which can contain states of subprocesses, e.g. state.State.
+type NestedStateInterface interface {
+	StateInterface
+	NewChild(transitionID string) (*state.State, error)
+	GetChild(transitionID string) (*state.State, bool)
+	RemoveChild(transitionID string)
+}
+
+// CancellableStateInterface is a state which supports cancellation regions of transitions, e.g. state.State.
+type CancellableStateInterface interface {
+	StateInterface
+	CancelTokens(ctx context.Context, places []string) ([]string, error)
+}
+
+// OutcomeStateInterface is a state which stores the outcome of finishing, e.g. state.State.
+type OutcomeStateInterface interface {
+	StateInterface
+	SetFinishedWithOutcome(outcome string) error
+	GetOutcome() string
+}
+
 type ListenerInterface interface {
 	BeforeStart(ctx context.Context) error
 	AfterStart(ctx context.Context)
@@ -57,6 +84,12 @@ type Net struct {
 	placeMap      map[string]cfg.IDGetter
 	transitionMap map[string]cfg.TransitionInterface
 	listener      ListenerInterface
+	registry      *cfg.Registry
+
+	completionPolicy CompletionPolicy
+	compensations    map[compensationKey]CompensationHandler
+	// subprocess is the name of the config of the net if it runs a subprocess.
+	subprocess string
 }
 
 func NewNet(config cfg.Interface) *Net {
@@ -78,26 +111,56 @@ func (n *Net) WithListener(listener ListenerInterface) {
 	n.listener = listener
 }
 
+// WithRegistry set registry of configs which are used by subprocess transitions.
+func (n *Net) WithRegistry(registry *cfg.Registry) {
+	n.registry = registry
+}
+
+// GetCfg returns config of the net.
+func (n *Net) GetCfg() cfg.Interface {
+	return n.cfg
+}
+
 // Start workflow net.
 //
 // Use ctx for cancel operation and send subject of operation.
+// Listeners get ctx derived for the start, so concurrent starts with the same ctx can be told apart.
 func (n *Net) Start(ctx context.Context, s StateInterface) error {
 	if s.IsStarted() {
 		return state.NewError(state.ErrCodeStateAlreadyStarted, "State already started in net")
 	}
 
+	ctx = context.WithValue(ctx, ctxStart, s)
+
 	if err := n.listener.BeforeStart(ctx); err != nil {
-		return err
+		return n.notifyStartFailure(ctx, err)
+	}
+
+	if err := n.process(ctx, s, []string{}, buildStringSliceFromIDGetter(n.cfg.GetStart()), nil); err != nil {
+		if _, ok := n.listener.(FailureListenerInterface); !ok {
+			n.listener.AfterStart(ctx)
+		}
+
+		return n.notifyStartFailure(ctx, err)
 	}
 
-	defer n.listener.AfterStart(ctx)
+	n.listener.AfterStart(ctx)
 
-	return n.process(ctx, s, []string{}, buildStringSliceFromIDGetter(n.cfg.GetStart()))
+	return nil
 }
 
 // Transit to new places(state).
 //
 // Use ctx for cancel operation and send subject of operation.
+// Listeners and the state get ctx with the transition id, see state.GetTransitionID.
+//
+// If the transition is a subprocess, the state of the subprocess is started and
+// the transition will be completed when the subprocess is finished, see TransitSubprocess.
+// Input places of a running subprocess are reserved for its completion, other transitions can't consume them.
+// If the subprocess is finished, but the completion of the transition failed, the transition is completed again.
+//
+// If BeforeTransition or a BeforeMove listener fails and the transition has an error place,
+// tokens are moved from input places to the error place.
 func (n *Net) Transit(ctx context.Context, s StateInterface, transitionID string) error {
 	if !s.IsStarted() {
 		return state.NewError(state.ErrCodeStateIsNotStarted, "Can't transit, state is not started")
@@ -112,19 +175,196 @@ func (n *Net) Transit(ctx context.Context, s StateInterface, transitionID string
 		)
 	}
 
-	if err := n.listener.BeforeTransition(ctx, transitionID, s); err != nil {
+	if err := n.checkSubprocessPlaces(s, transitionID); err != nil {
 		return err
 	}
 
+	trCtx := state.WithTransitionID(ctx, transitionID)
+
+	if getSubprocess(transition) != "" {
+		return n.startSubprocess(ctx, s, transitionID)
+	}
+
+	if err := n.listener.BeforeTransition(trCtx, transitionID, s); err != nil {
+		return n.notifyTransitionFailure(trCtx, transitionID, s, n.routeToErrorPlace(trCtx, s, transitionID, err))
+	}
+
+	return n.completeTransition(trCtx, s, transitionID)
+}
+
+// TransitSubprocess transits the state of the subprocess.
+//
+// Path is the list of ids of subprocess transitions from the state to the state of the subprocess,
+// so for a subprocess in a subprocess the path contains two ids.
+// When the subprocess is finished, the transition which runs it is completed.
+func (n *Net) TransitSubprocess(ctx context.Context, s StateInterface, path []string, transitionID string) error {
+	if len(path) == 0 {
+		return n.Transit(ctx, s, transitionID)
+	}
+
+	nested, ok := s.(NestedStateInterface)
+	if !ok {
+		return state.NewError(state.ErrCodeNetStateDoesntSupportNesting, "State doesn't support subprocesses")
+	}
+
+	child, ok := nested.GetChild(path[0])
+	if !ok {
+		return state.NewErrorf(
+			state.ErrCodeStateSubprocessIsNotStarted,
+			"Subprocess of transition '%s' is not started",
+			path[0],
+		)
+	}
+
+	subNet, err := n.getSubprocessNet(path[0])
+	if err != nil {
+		return err
+	}
+
+	if err := subNet.TransitSubprocess(ctx, child, path[1:], transitionID); err != nil {
+		return err
+	}
+
+	if !child.IsFinished() {
+		return nil
+	}
+
+	return n.completeSubprocess(state.WithTransitionID(ctx, path[0]), nested, path[0])
+}
+
+func (n *Net) startSubprocess(ctx context.Context, s StateInterface, transitionID string) error {
+	nested, ok := s.(NestedStateInterface)
+	if !ok {
+		return state.NewError(state.ErrCodeNetStateDoesntSupportNesting, "State doesn't support subprocesses")
+	}
+
+	subNet, err := n.getSubprocessNet(transitionID)
+	if err != nil {
+		return err
+	}
+
+	if s.IsError() {
+		return state.NewError(state.ErrCodeStateIsErrorState, "Can't start subprocess, state is errStack")
+	}
+
+	if s.IsFinished() {
+		return state.NewError(state.ErrCodeStateIsFinished, "Can't start subprocess, state is finished")
+	}
+
+	if err := n.checkTokens(s, n.transitionMap[transitionID].GetFrom()); err != nil {
+		return err
+	}
+
+	trCtx := state.WithTransitionID(ctx, transitionID)
+
+	if err := n.listener.BeforeTransition(trCtx, transitionID, s); err != nil {
+		return n.notifyTransitionFailure(trCtx, transitionID, s, n.routeToErrorPlace(trCtx, s, transitionID, err))
+	}
+
+	// The subprocess is finished, but the completion of the transition failed, so it is completed again.
+	if child, ok := nested.GetChild(transitionID); ok && child.IsFinished() {
+		return n.completeSubprocess(trCtx, nested, transitionID)
+	}
+
+	child, err := nested.NewChild(transitionID)
+	if err != nil {
+		return n.notifyTransitionFailure(trCtx, transitionID, s, err)
+	}
+
+	if err := subNet.Start(ctx, child); err != nil {
+		nested.RemoveChild(transitionID)
+
+		return n.notifyTransitionFailure(trCtx, transitionID, s, err)
+	}
+
+	if !child.IsFinished() {
+		return nil
+	}
+
+	return n.completeSubprocess(trCtx, nested, transitionID)
+}
+
+// checkSubprocessPlaces returns err if the transition consumes a token of an input place of a running subprocess.
+// The token stays in the place while the subprocess runs, it is consumed when the subprocess is finished.
+func (n *Net) checkSubprocessPlaces(s StateInterface, transitionID string) error {
+	nested, ok := s.(NestedStateInterface)
+	if !ok {
+		return nil
+	}
+
+	from := make(map[string]struct{})
+	for _, place := range n.transitionMap[transitionID].GetFrom() {
+		from[place.GetID()] = struct{}{}
+	}
+
+	for _, id := range n.getSubprocessTransitionIDs() {
+		if id == transitionID {
+			continue
+		}
+
+		if _, ok := nested.GetChild(id); !ok {
+			continue
+		}
+
+		for _, place := range n.transitionMap[id].GetFrom() {
+			if _, ok := from[place.GetID()]; ok {
+				return state.NewErrorf(
+					state.ErrCodeNetPlaceIsReservedBySubprocess,
+					"Can't transit, place '%s' is reserved by running subprocess of transition '%s'",
+					place.GetID(),
+					id,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getSubprocessTransitionIDs returns sorted ids of subprocess transitions.
+func (n *Net) getSubprocessTransitionIDs() []string {
+	res := make([]string, 0)
+
+	for id, transition := range n.transitionMap {
+		if getSubprocess(transition) != "" {
+			res = append(res, id)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+func (n *Net) completeSubprocess(ctx context.Context, s NestedStateInterface, transitionID string) error {
+	if err := n.completeTransition(ctx, s, transitionID); err != nil {
+		if state.ErrorIs(state.ErrCodeNetTransitionIsRoutedToErrorPlace, err) {
+			s.RemoveChild(transitionID)
+		}
+
+		return err
+	}
+
+	s.RemoveChild(transitionID)
+
+	return nil
+}
+
+func (n *Net) completeTransition(ctx context.Context, s StateInterface, transitionID string) error {
+	transition := n.transitionMap[transitionID]
+	toPlaces := buildStringSliceFromIDGetter(transition.GetTo()...)
+	cancelPlaces := buildCancelRegion(transition, toPlaces)
+
 	err := n.process(
 		ctx,
 		s,
 		buildStringSliceFromIDGetter(transition.GetFrom()...),
-		buildStringSliceFromIDGetter(transition.GetTo()...),
+		toPlaces,
+		cancelPlaces,
 	)
 
 	if err != nil {
-		return err
+		return n.notifyTransitionFailure(ctx, transitionID, s, n.routeToErrorPlace(ctx, s, transitionID, err))
 	}
 
 	n.listener.AfterTransition(ctx, transitionID, s)
@@ -132,24 +372,90 @@ func (n *Net) Transit(ctx context.Context, s StateInterface, transitionID string
 	return nil
 }
 
-func (n *Net) process(ctx context.Context, s StateInterface, fromPlaces []string, toPlaces []string) error {
+func (n *Net) getSubprocessNet(transitionID string) (*Net, error) {
+	if n.registry == nil {
+		return nil, state.NewError(state.ErrCodeNetHasNotRegistry, "Net has not registry of configs for subprocesses")
+	}
+
+	config, err := n.registry.GetByName(getSubprocess(n.transitionMap[transitionID]))
+	if err != nil {
+		return nil, err
+	}
+
+	subNet := NewNet(config)
+	subNet.WithListener(n.listener)
+	subNet.WithRegistry(n.registry)
+	subNet.WithCompletionPolicy(n.completionPolicy)
+	subNet.compensations = n.compensations
+	subNet.subprocess = getSubprocess(n.transitionMap[transitionID])
+
+	return subNet, nil
+}
+
+func (n *Net) checkTokens(s StateReadInterface, places []cfg.IDGetter) error {
+	marked := make(map[string]struct{})
+	for _, place := range s.GetPlaces() {
+		marked[place] = struct{}{}
+	}
+
+	for _, place := range places {
+		if _, ok := marked[place.GetID()]; !ok {
+			return state.NewErrorf(
+				state.ErrCodeStateHasNotTokenInPlace,
+				"State has not token in place '%s', state places: %+v",
+				place.GetID(), s.GetPlaces(),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (n *Net) process(
+	ctx context.Context,
+	s StateInterface,
+	fromPlaces []string,
+	toPlaces []string,
+	cancelPlaces []string,
+) error {
 	if n.listener.HasStateListener() {
 		s.WithListener(n.listener.GetStateListener())
 	}
 
+	if _, ok := s.(CancellableStateInterface); !ok && len(cancelPlaces) > 0 {
+		return state.NewError(state.ErrCodeNetStateDoesntSupportCancellation, "State doesn't support cancellation regions")
+	}
+
+	if err := n.checkCompletion(s, fromPlaces, toPlaces, cancelPlaces); err != nil {
+		return err
+	}
+
 	if err := s.MoveTokensFromPlacesToPlaces(ctx, fromPlaces, toPlaces); err != nil {
 		return err
 	}
 
+	if err := n.cancelTokens(ctx, s, cancelPlaces); err != nil {
+		return err
+	}
+
 	if len(toPlaces) != 1 {
 		return nil
 	}
 
-	if toPlaces[0] == n.cfg.GetFinish().GetID() {
-		return s.SetFinished()
+	outcome, ok := cfg.GetTerminalPlaces(n.cfg)[toPlaces[0]]
+	if !ok {
+		return nil
+	}
+
+	if err := n.clearOnCompletion(ctx, s, toPlaces); err != nil {
+		return err
 	}
 
-	return nil
+	if outcomeState, ok := s.(OutcomeStateInterface); ok {
+		return outcomeState.SetFinishedWithOutcome(outcome)
+	}
+
+	return s.SetFinished()
 }
 
 func buildStringSliceFromIDGetter(in ...cfg.IDGetter) []string {
@@ -160,3 +466,78 @@ func buildStringSliceFromIDGetter(in ...cfg.IDGetter) []string {
 
 	return res
 }
+
+func getSubprocess(transition cfg.TransitionInterface) string {
+	sub, ok := transition.(cfg.SubprocessTransitionInterface)
+	if !ok {
+		return ""
+	}
+
+	return sub.GetSubprocess()
+}
+
+// cancelTokens removes tokens from places and states of subprocesses which are run by tokens of the places.
+func (n *Net) cancelTokens(ctx context.Context, s StateInterface, places []string) error {
+	if len(places) == 0 {
+		return nil
+	}
+
+	cancelled, err := s.(CancellableStateInterface).CancelTokens(ctx, places)
+	if err != nil {
+		return err
+	}
+
+	n.removeCancelledSubprocesses(ctx, s, cancelled)
+
+	return nil
+}
+
+// removeCancelledSubprocesses removes states of running subprocesses which input places are cancelled.
+func (n *Net) removeCancelledSubprocesses(ctx context.Context, s StateInterface, cancelled []string) {
+	nested, ok := s.(NestedStateInterface)
+	if !ok || len(cancelled) == 0 {
+		return
+	}
+
+	cancelledMap := make(map[string]struct{}, len(cancelled))
+	for _, place := range cancelled {
+		cancelledMap[place] = struct{}{}
+	}
+
+	for _, id := range n.getSubprocessTransitionIDs() {
+		if _, ok := nested.GetChild(id); !ok {
+			continue
+		}
+
+		for _, place := range n.transitionMap[id].GetFrom() {
+			if _, ok := cancelledMap[place.GetID()]; ok {
+				n.removeSubprocess(ctx, nested, id)
+
+				break
+			}
+		}
+	}
+}
+
+// buildCancelRegion returns places of cancellation region of the transition except its output places.
+func buildCancelRegion(transition cfg.TransitionInterface, toPlaces []string) []string {
+	cancelling, ok := transition.(cfg.CancellingTransitionInterface)
+	if !ok {
+		return nil
+	}
+
+	toMap := make(map[string]struct{}, len(toPlaces))
+	for _, place := range toPlaces {
+		toMap[place] = struct{}{}
+	}
+
+	res := make([]string, 0, len(cancelling.GetCancel()))
+
+	for _, place := range cancelling.GetCancel() {
+		if _, ok := toMap[place.GetID()]; !ok {
+			res = append(res, place.GetID())
+		}
+	}
+
+	return res
+}
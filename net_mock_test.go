@@ -368,6 +368,526 @@ func (mr *MockStateInterfaceMockRecorder) MoveTokensFromPlacesToPlaces(ctx, from
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTokensFromPlacesToPlaces", reflect.TypeOf((*MockStateInterface)(nil).MoveTokensFromPlacesToPlaces), ctx, from, to)
 }
 
+// MockNestedStateInterface is a mock of NestedStateInterface interface
+type MockNestedStateInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockNestedStateInterfaceMockRecorder
+}
+
+// MockNestedStateInterfaceMockRecorder is the mock recorder for MockNestedStateInterface
+type MockNestedStateInterfaceMockRecorder struct {
+	mock *MockNestedStateInterface
+}
+
+// NewMockNestedStateInterface creates a new mock instance
+func NewMockNestedStateInterface(ctrl *gomock.Controller) *MockNestedStateInterface {
+	mock := &MockNestedStateInterface{ctrl: ctrl}
+	mock.recorder = &MockNestedStateInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockNestedStateInterface) EXPECT() *MockNestedStateInterfaceMockRecorder {
+	return m.recorder
+}
+
+// IsStarted mocks base method
+func (m *MockNestedStateInterface) IsStarted() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsStarted")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsStarted indicates an expected call of IsStarted
+func (mr *MockNestedStateInterfaceMockRecorder) IsStarted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsStarted", reflect.TypeOf((*MockNestedStateInterface)(nil).IsStarted))
+}
+
+// IsFinished mocks base method
+func (m *MockNestedStateInterface) IsFinished() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFinished")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsFinished indicates an expected call of IsFinished
+func (mr *MockNestedStateInterfaceMockRecorder) IsFinished() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFinished", reflect.TypeOf((*MockNestedStateInterface)(nil).IsFinished))
+}
+
+// IsError mocks base method
+func (m *MockNestedStateInterface) IsError() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsError")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsError indicates an expected call of IsError
+func (mr *MockNestedStateInterfaceMockRecorder) IsError() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsError", reflect.TypeOf((*MockNestedStateInterface)(nil).IsError))
+}
+
+// GetErrorStack mocks base method
+func (m *MockNestedStateInterface) GetErrorStack() state.ErrStackInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetErrorStack")
+	ret0, _ := ret[0].(state.ErrStackInterface)
+	return ret0
+}
+
+// GetErrorStack indicates an expected call of GetErrorStack
+func (mr *MockNestedStateInterfaceMockRecorder) GetErrorStack() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetErrorStack", reflect.TypeOf((*MockNestedStateInterface)(nil).GetErrorStack))
+}
+
+// GetPlaces mocks base method
+func (m *MockNestedStateInterface) GetPlaces() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaces")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetPlaces indicates an expected call of GetPlaces
+func (mr *MockNestedStateInterfaceMockRecorder) GetPlaces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaces", reflect.TypeOf((*MockNestedStateInterface)(nil).GetPlaces))
+}
+
+// SetFinished mocks base method
+func (m *MockNestedStateInterface) SetFinished() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFinished")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFinished indicates an expected call of SetFinished
+func (mr *MockNestedStateInterfaceMockRecorder) SetFinished() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFinished", reflect.TypeOf((*MockNestedStateInterface)(nil).SetFinished))
+}
+
+// AddError mocks base method
+func (m *MockNestedStateInterface) AddError(err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddError", err)
+}
+
+// AddError indicates an expected call of AddError
+func (mr *MockNestedStateInterfaceMockRecorder) AddError(err interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddError", reflect.TypeOf((*MockNestedStateInterface)(nil).AddError), err)
+}
+
+// WithListener mocks base method
+func (m *MockNestedStateInterface) WithListener(listener state.ListenerInterface) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "WithListener", listener)
+}
+
+// WithListener indicates an expected call of WithListener
+func (mr *MockNestedStateInterfaceMockRecorder) WithListener(listener interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithListener", reflect.TypeOf((*MockNestedStateInterface)(nil).WithListener), listener)
+}
+
+// MoveTokensFromPlacesToPlaces mocks base method
+func (m *MockNestedStateInterface) MoveTokensFromPlacesToPlaces(ctx context.Context, from, to []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTokensFromPlacesToPlaces", ctx, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveTokensFromPlacesToPlaces indicates an expected call of MoveTokensFromPlacesToPlaces
+func (mr *MockNestedStateInterfaceMockRecorder) MoveTokensFromPlacesToPlaces(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTokensFromPlacesToPlaces", reflect.TypeOf((*MockNestedStateInterface)(nil).MoveTokensFromPlacesToPlaces), ctx, from, to)
+}
+
+// NewChild mocks base method
+func (m *MockNestedStateInterface) NewChild(transitionID string) (*state.State, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewChild", transitionID)
+	ret0, _ := ret[0].(*state.State)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewChild indicates an expected call of NewChild
+func (mr *MockNestedStateInterfaceMockRecorder) NewChild(transitionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewChild", reflect.TypeOf((*MockNestedStateInterface)(nil).NewChild), transitionID)
+}
+
+// GetChild mocks base method
+func (m *MockNestedStateInterface) GetChild(transitionID string) (*state.State, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChild", transitionID)
+	ret0, _ := ret[0].(*state.State)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetChild indicates an expected call of GetChild
+func (mr *MockNestedStateInterfaceMockRecorder) GetChild(transitionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChild", reflect.TypeOf((*MockNestedStateInterface)(nil).GetChild), transitionID)
+}
+
+// RemoveChild mocks base method
+func (m *MockNestedStateInterface) RemoveChild(transitionID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveChild", transitionID)
+}
+
+// RemoveChild indicates an expected call of RemoveChild
+func (mr *MockNestedStateInterfaceMockRecorder) RemoveChild(transitionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveChild", reflect.TypeOf((*MockNestedStateInterface)(nil).RemoveChild), transitionID)
+}
+
+// MockCancellableStateInterface is a mock of CancellableStateInterface interface
+type MockCancellableStateInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCancellableStateInterfaceMockRecorder
+}
+
+// MockCancellableStateInterfaceMockRecorder is the mock recorder for MockCancellableStateInterface
+type MockCancellableStateInterfaceMockRecorder struct {
+	mock *MockCancellableStateInterface
+}
+
+// NewMockCancellableStateInterface creates a new mock instance
+func NewMockCancellableStateInterface(ctrl *gomock.Controller) *MockCancellableStateInterface {
+	mock := &MockCancellableStateInterface{ctrl: ctrl}
+	mock.recorder = &MockCancellableStateInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCancellableStateInterface) EXPECT() *MockCancellableStateInterfaceMockRecorder {
+	return m.recorder
+}
+
+// IsStarted mocks base method
+func (m *MockCancellableStateInterface) IsStarted() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsStarted")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsStarted indicates an expected call of IsStarted
+func (mr *MockCancellableStateInterfaceMockRecorder) IsStarted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsStarted", reflect.TypeOf((*MockCancellableStateInterface)(nil).IsStarted))
+}
+
+// IsFinished mocks base method
+func (m *MockCancellableStateInterface) IsFinished() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFinished")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsFinished indicates an expected call of IsFinished
+func (mr *MockCancellableStateInterfaceMockRecorder) IsFinished() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFinished", reflect.TypeOf((*MockCancellableStateInterface)(nil).IsFinished))
+}
+
+// IsError mocks base method
+func (m *MockCancellableStateInterface) IsError() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsError")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsError indicates an expected call of IsError
+func (mr *MockCancellableStateInterfaceMockRecorder) IsError() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsError", reflect.TypeOf((*MockCancellableStateInterface)(nil).IsError))
+}
+
+// GetErrorStack mocks base method
+func (m *MockCancellableStateInterface) GetErrorStack() state.ErrStackInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetErrorStack")
+	ret0, _ := ret[0].(state.ErrStackInterface)
+	return ret0
+}
+
+// GetErrorStack indicates an expected call of GetErrorStack
+func (mr *MockCancellableStateInterfaceMockRecorder) GetErrorStack() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetErrorStack", reflect.TypeOf((*MockCancellableStateInterface)(nil).GetErrorStack))
+}
+
+// GetPlaces mocks base method
+func (m *MockCancellableStateInterface) GetPlaces() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaces")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetPlaces indicates an expected call of GetPlaces
+func (mr *MockCancellableStateInterfaceMockRecorder) GetPlaces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaces", reflect.TypeOf((*MockCancellableStateInterface)(nil).GetPlaces))
+}
+
+// SetFinished mocks base method
+func (m *MockCancellableStateInterface) SetFinished() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFinished")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFinished indicates an expected call of SetFinished
+func (mr *MockCancellableStateInterfaceMockRecorder) SetFinished() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFinished", reflect.TypeOf((*MockCancellableStateInterface)(nil).SetFinished))
+}
+
+// AddError mocks base method
+func (m *MockCancellableStateInterface) AddError(err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddError", err)
+}
+
+// AddError indicates an expected call of AddError
+func (mr *MockCancellableStateInterfaceMockRecorder) AddError(err interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddError", reflect.TypeOf((*MockCancellableStateInterface)(nil).AddError), err)
+}
+
+// WithListener mocks base method
+func (m *MockCancellableStateInterface) WithListener(listener state.ListenerInterface) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "WithListener", listener)
+}
+
+// WithListener indicates an expected call of WithListener
+func (mr *MockCancellableStateInterfaceMockRecorder) WithListener(listener interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithListener", reflect.TypeOf((*MockCancellableStateInterface)(nil).WithListener), listener)
+}
+
+// MoveTokensFromPlacesToPlaces mocks base method
+func (m *MockCancellableStateInterface) MoveTokensFromPlacesToPlaces(ctx context.Context, from, to []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTokensFromPlacesToPlaces", ctx, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveTokensFromPlacesToPlaces indicates an expected call of MoveTokensFromPlacesToPlaces
+func (mr *MockCancellableStateInterfaceMockRecorder) MoveTokensFromPlacesToPlaces(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTokensFromPlacesToPlaces", reflect.TypeOf((*MockCancellableStateInterface)(nil).MoveTokensFromPlacesToPlaces), ctx, from, to)
+}
+
+// CancelTokens mocks base method
+func (m *MockCancellableStateInterface) CancelTokens(ctx context.Context, places []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelTokens", ctx, places)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelTokens indicates an expected call of CancelTokens
+func (mr *MockCancellableStateInterfaceMockRecorder) CancelTokens(ctx, places interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelTokens", reflect.TypeOf((*MockCancellableStateInterface)(nil).CancelTokens), ctx, places)
+}
+
+// MockOutcomeStateInterface is a mock of OutcomeStateInterface interface
+type MockOutcomeStateInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutcomeStateInterfaceMockRecorder
+}
+
+// MockOutcomeStateInterfaceMockRecorder is the mock recorder for MockOutcomeStateInterface
+type MockOutcomeStateInterfaceMockRecorder struct {
+	mock *MockOutcomeStateInterface
+}
+
+// NewMockOutcomeStateInterface creates a new mock instance
+func NewMockOutcomeStateInterface(ctrl *gomock.Controller) *MockOutcomeStateInterface {
+	mock := &MockOutcomeStateInterface{ctrl: ctrl}
+	mock.recorder = &MockOutcomeStateInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockOutcomeStateInterface) EXPECT() *MockOutcomeStateInterfaceMockRecorder {
+	return m.recorder
+}
+
+// IsStarted mocks base method
+func (m *MockOutcomeStateInterface) IsStarted() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsStarted")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsStarted indicates an expected call of IsStarted
+func (mr *MockOutcomeStateInterfaceMockRecorder) IsStarted() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsStarted", reflect.TypeOf((*MockOutcomeStateInterface)(nil).IsStarted))
+}
+
+// IsFinished mocks base method
+func (m *MockOutcomeStateInterface) IsFinished() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFinished")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsFinished indicates an expected call of IsFinished
+func (mr *MockOutcomeStateInterfaceMockRecorder) IsFinished() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFinished", reflect.TypeOf((*MockOutcomeStateInterface)(nil).IsFinished))
+}
+
+// IsError mocks base method
+func (m *MockOutcomeStateInterface) IsError() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsError")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsError indicates an expected call of IsError
+func (mr *MockOutcomeStateInterfaceMockRecorder) IsError() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsError", reflect.TypeOf((*MockOutcomeStateInterface)(nil).IsError))
+}
+
+// GetErrorStack mocks base method
+func (m *MockOutcomeStateInterface) GetErrorStack() state.ErrStackInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetErrorStack")
+	ret0, _ := ret[0].(state.ErrStackInterface)
+	return ret0
+}
+
+// GetErrorStack indicates an expected call of GetErrorStack
+func (mr *MockOutcomeStateInterfaceMockRecorder) GetErrorStack() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetErrorStack", reflect.TypeOf((*MockOutcomeStateInterface)(nil).GetErrorStack))
+}
+
+// GetPlaces mocks base method
+func (m *MockOutcomeStateInterface) GetPlaces() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaces")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetPlaces indicates an expected call of GetPlaces
+func (mr *MockOutcomeStateInterfaceMockRecorder) GetPlaces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaces", reflect.TypeOf((*MockOutcomeStateInterface)(nil).GetPlaces))
+}
+
+// SetFinished mocks base method
+func (m *MockOutcomeStateInterface) SetFinished() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFinished")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFinished indicates an expected call of SetFinished
+func (mr *MockOutcomeStateInterfaceMockRecorder) SetFinished() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFinished", reflect.TypeOf((*MockOutcomeStateInterface)(nil).SetFinished))
+}
+
+// AddError mocks base method
+func (m *MockOutcomeStateInterface) AddError(err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddError", err)
+}
+
+// AddError indicates an expected call of AddError
+func (mr *MockOutcomeStateInterfaceMockRecorder) AddError(err interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddError", reflect.TypeOf((*MockOutcomeStateInterface)(nil).AddError), err)
+}
+
+// WithListener mocks base method
+func (m *MockOutcomeStateInterface) WithListener(listener state.ListenerInterface) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "WithListener", listener)
+}
+
+// WithListener indicates an expected call of WithListener
+func (mr *MockOutcomeStateInterfaceMockRecorder) WithListener(listener interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithListener", reflect.TypeOf((*MockOutcomeStateInterface)(nil).WithListener), listener)
+}
+
+// MoveTokensFromPlacesToPlaces mocks base method
+func (m *MockOutcomeStateInterface) MoveTokensFromPlacesToPlaces(ctx context.Context, from, to []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTokensFromPlacesToPlaces", ctx, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveTokensFromPlacesToPlaces indicates an expected call of MoveTokensFromPlacesToPlaces
+func (mr *MockOutcomeStateInterfaceMockRecorder) MoveTokensFromPlacesToPlaces(ctx, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTokensFromPlacesToPlaces", reflect.TypeOf((*MockOutcomeStateInterface)(nil).MoveTokensFromPlacesToPlaces), ctx, from, to)
+}
+
+// SetFinishedWithOutcome mocks base method
+func (m *MockOutcomeStateInterface) SetFinishedWithOutcome(outcome string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFinishedWithOutcome", outcome)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFinishedWithOutcome indicates an expected call of SetFinishedWithOutcome
+func (mr *MockOutcomeStateInterfaceMockRecorder) SetFinishedWithOutcome(outcome interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFinishedWithOutcome", reflect.TypeOf((*MockOutcomeStateInterface)(nil).SetFinishedWithOutcome), outcome)
+}
+
+// GetOutcome mocks base method
+func (m *MockOutcomeStateInterface) GetOutcome() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutcome")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetOutcome indicates an expected call of GetOutcome
+func (mr *MockOutcomeStateInterfaceMockRecorder) GetOutcome() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutcome", reflect.TypeOf((*MockOutcomeStateInterface)(nil).GetOutcome))
+}
+
 // MockListenerInterface is a mock of ListenerInterface interface
 type MockListenerInterface struct {
 	ctrl     *gomock.Controller
@@ -7,6 +7,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/andrskom/gowfnet/cfg"
 	mocks "github.com/andrskom/gowfnet/moscks"
@@ -22,12 +23,57 @@ func TestCtxSubject(t *testing.T) {
 	assert.Same(t, eSubj, aSubj)
 }
 
+type testingCtxListener struct {
+	*StubListener
+	ctxs []context.Context
+}
+
+func (l *testingCtxListener) BeforeStart(ctx context.Context) error {
+	l.ctxs = append(l.ctxs, ctx)
+
+	return nil
+}
+
+func TestNet_Start_TheSameCtx_ListenerGetsDifferentCtx(t *testing.T) {
+	listener := &testingCtxListener{StubListener: NewStubListener()}
+
+	net := NewNet(cfg.Minimal{
+		Start:       "start",
+		Finish:      "finish",
+		Places:      []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{"t": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}}},
+	})
+	net.WithListener(listener)
+
+	ctx := SetSubject(context.Background(), "a")
+	require.NoError(t, net.Start(ctx, state.NewState()))
+	require.NoError(t, net.Start(ctx, state.NewState()))
+
+	require.Len(t, listener.ctxs, 2)
+	assert.True(t, listener.ctxs[0] != listener.ctxs[1], "ctx of starts must be different")
+
+	subject, _ := GetSubject(listener.ctxs[0])
+	assert.Equal(t, "a", subject)
+}
+
 func TestGetSubject_NotSetSubject_ReturnsNotOk(t *testing.T) {
 	aSubj, ok := GetSubject(context.Background())
 	assert.False(t, ok)
 	assert.Nil(t, aSubj)
 }
 
+func TestGetSubject_WithTransitionID_SubjectIsNotOverwritten(t *testing.T) {
+	ctx := state.WithTransitionID(SetSubject(context.Background(), "subj"), "t")
+
+	aSubj, ok := GetSubject(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "subj", aSubj)
+
+	transitionID, ok := state.GetTransitionID(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "t", transitionID)
+}
+
 func TestNet_Start_AlreadyStarted_ReturnsErr(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -69,7 +115,7 @@ func TestNet_Process_MoveToStartErr_ExpectedErr(t *testing.T) {
 	st.EXPECT().IsStarted().Return(false)
 
 	eErr := errors.New("a")
-	st.EXPECT().MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"b"}).Return(eErr)
+	st.EXPECT().MoveTokensFromPlacesToPlaces(startCtx(st), []string{}, []string{"b"}).Return(eErr)
 
 	err := net.Start(context.Background(), st)
 	assert.Same(t, eErr, err)
@@ -87,7 +133,7 @@ func TestNet_Process_StartToFirstPlaceIsFinishWithoutErr_ReturnsNil(t *testing.T
 	net := NewNet(config)
 	st := NewMockStateInterface(ctrl)
 	st.EXPECT().IsStarted().Return(false)
-	st.EXPECT().MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"b"}).Return(nil)
+	st.EXPECT().MoveTokensFromPlacesToPlaces(startCtx(st), []string{}, []string{"b"}).Return(nil)
 	st.EXPECT().SetFinished().Return(nil)
 
 	err := net.Start(context.Background(), st)
@@ -106,7 +152,7 @@ func TestNet_Process_StartToFirstPlaceIsFinishWithErr_ReturnsTheSameErr(t *testi
 	net := NewNet(config)
 	st := NewMockStateInterface(ctrl)
 	st.EXPECT().IsStarted().Return(false)
-	st.EXPECT().MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"b"}).Return(nil)
+	st.EXPECT().MoveTokensFromPlacesToPlaces(startCtx(st), []string{}, []string{"b"}).Return(nil)
 
 	eErr := errors.New("a")
 	st.EXPECT().SetFinished().Return(eErr)
@@ -127,7 +173,7 @@ func TestNet_Process_StartToOnePlaceIsNotFinish_NoErr(t *testing.T) {
 	net := NewNet(config)
 	st := NewMockStateInterface(ctrl)
 	st.EXPECT().IsStarted().Return(false)
-	st.EXPECT().MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"b"}).Return(nil)
+	st.EXPECT().MoveTokensFromPlacesToPlaces(startCtx(st), []string{}, []string{"b"}).Return(nil)
 
 	err := net.Start(context.Background(), st)
 	assert.NoError(t, err)
@@ -150,7 +196,7 @@ func TestNet_Process_StartToSomePlaces_NoErr(t *testing.T) {
 	net := NewNet(config)
 	st := NewMockStateInterface(ctrl)
 	st.EXPECT().IsStarted().Return(true)
-	st.EXPECT().MoveTokensFromPlacesToPlaces(context.Background(), []string{"h"}, []string{"e", "f"}).Return(nil)
+	st.EXPECT().MoveTokensFromPlacesToPlaces(transitionCtx("t"), []string{"h"}, []string{"e", "f"}).Return(nil)
 
 	err := net.Transit(context.Background(), st, "t")
 	assert.NoError(t, err)
@@ -218,3 +264,336 @@ func TestState_WithListener(t *testing.T) {
 
 	assert.Same(t, listener, net.listener)
 }
+
+func TestNet_GetCfg(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	config := mocks.NewMockInterface(ctrl)
+	config.EXPECT().GetTransitions().Return(cfg.MinimalTransitionRegistry{})
+	config.EXPECT().GetPlaces().Return([]cfg.IDGetter{})
+
+	assert.Same(t, config, NewNet(config).GetCfg())
+}
+
+type transitionCtxMatcher struct {
+	transitionID string
+}
+
+// transitionCtx matches ctx which contains the transition id.
+func transitionCtx(transitionID string) gomock.Matcher {
+	return transitionCtxMatcher{transitionID: transitionID}
+}
+
+func (m transitionCtxMatcher) Matches(x interface{}) bool {
+	ctx, ok := x.(context.Context)
+	if !ok {
+		return false
+	}
+
+	transitionID, ok := state.GetTransitionID(ctx)
+
+	return ok && transitionID == m.transitionID
+}
+
+func (m transitionCtxMatcher) String() string {
+	return "is ctx with transition id " + m.transitionID
+}
+
+type startCtxMatcher struct {
+	state StateInterface
+}
+
+// startCtx matches ctx which is derived by Start of the state.
+func startCtx(s StateInterface) gomock.Matcher {
+	return startCtxMatcher{state: s}
+}
+
+func (m startCtxMatcher) Matches(x interface{}) bool {
+	ctx, ok := x.(context.Context)
+
+	return ok && ctx.Value(ctxStart) == m.state
+}
+
+func (m startCtxMatcher) String() string {
+	return "is ctx of start of the state"
+}
+
+func newSubprocessNet(t *testing.T) *Net {
+	review := cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"approve": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}},
+		},
+	}
+	main := cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"review": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}, Subprocess: "review"},
+			"skip":   {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}},
+		},
+	}
+
+	registry := cfg.NewRegistry()
+	require.NoError(t, registry.AddWithName("review", review))
+
+	net := NewNet(main)
+	net.WithRegistry(registry)
+
+	return net
+}
+
+func TestNet_Transit_Subprocess_ChildIsStarted(t *testing.T) {
+	net := newSubprocessNet(t)
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+
+	require.NoError(t, net.Transit(context.Background(), st, "review"))
+
+	assert.Equal(t, []string{"start"}, st.GetPlaces())
+
+	child, ok := st.GetChild("review")
+	require.True(t, ok)
+	assert.Equal(t, []string{"start"}, child.GetPlaces())
+}
+
+func TestNet_Transit_SubprocessWithoutRegistry_ExpectedErr(t *testing.T) {
+	net := newSubprocessNet(t)
+	net.WithRegistry(nil)
+
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+
+	err := net.Transit(context.Background(), st, "review")
+	assert.Equal(t, state.NewError(state.ErrCodeNetHasNotRegistry, "Net has not registry of configs for subprocesses"), err)
+}
+
+func TestNet_Transit_SubprocessNotNestedState_ExpectedErr(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	st := NewMockStateInterface(ctrl)
+	st.EXPECT().IsStarted().Return(true)
+
+	err := newSubprocessNet(t).Transit(context.Background(), st, "review")
+	assert.Equal(t, state.NewError(state.ErrCodeNetStateDoesntSupportNesting, "State doesn't support subprocesses"), err)
+}
+
+func TestNet_Transit_SubprocessAlreadyStarted_ExpectedErr(t *testing.T) {
+	net := newSubprocessNet(t)
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+	require.NoError(t, net.Transit(context.Background(), st, "review"))
+
+	err := net.Transit(context.Background(), st, "review")
+	assert.True(t, state.ErrorIs(state.ErrCodeStateSubprocessAlreadyStarted, err))
+}
+
+func TestNet_Transit_CompetingWithRunningSubprocess_ExpectedErr(t *testing.T) {
+	net := newSubprocessNet(t)
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+	require.NoError(t, net.Transit(context.Background(), st, "review"))
+
+	err := net.Transit(context.Background(), st, "skip")
+	assert.Equal(
+		t,
+		state.NewError(
+			state.ErrCodeNetPlaceIsReservedBySubprocess,
+			"Can't transit, place 'start' is reserved by running subprocess of transition 'review'",
+		),
+		err,
+	)
+	assert.Equal(t, []string{"start"}, st.GetPlaces())
+
+	require.NoError(t, net.TransitSubprocess(context.Background(), st, []string{"review"}, "approve"))
+	assert.True(t, st.IsFinished())
+}
+
+func TestNet_TransitSubprocess_ChildIsFinished_TransitionIsCompleted(t *testing.T) {
+	net := newSubprocessNet(t)
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+	require.NoError(t, net.Transit(context.Background(), st, "review"))
+
+	require.NoError(t, net.TransitSubprocess(context.Background(), st, []string{"review"}, "approve"))
+
+	assert.True(t, st.IsFinished())
+	assert.Equal(t, []string{"finish"}, st.GetPlaces())
+
+	_, ok := st.GetChild("review")
+	assert.False(t, ok)
+}
+
+type testingFailingOnceStateListener struct {
+	*state.StubListener
+	transitionID string
+	failed       bool
+}
+
+func (l *testingFailingOnceStateListener) BeforeMove(
+	ctx context.Context,
+	st state.OpInterface,
+	from []string,
+	to []string,
+) error {
+	if transitionID, ok := state.GetTransitionID(ctx); !ok || transitionID != l.transitionID || l.failed {
+		return nil
+	}
+
+	l.failed = true
+
+	return errors.New("move failed")
+}
+
+func TestNet_Retry_FinishedSubprocessWithFailedCompletion_TransitionIsCompleted(t *testing.T) {
+	net := newSubprocessNet(t)
+	net.WithListener(&testingFailingListener{
+		StubListener:  NewStubListener(),
+		stateListener: &testingFailingOnceStateListener{StubListener: state.NewStubListener(), transitionID: "review"},
+	})
+
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+	require.NoError(t, net.Transit(context.Background(), st, "review"))
+
+	err := net.TransitSubprocess(context.Background(), st, []string{"review"}, "approve")
+	require.EqualError(t, err, "move failed")
+
+	child, ok := st.GetChild("review")
+	require.True(t, ok)
+	assert.True(t, child.IsFinished())
+	assert.Equal(t, []string{"start"}, st.GetPlaces())
+
+	require.NoError(t, net.Retry(context.Background(), st, "review", "listener is repaired"))
+	assert.True(t, st.IsFinished())
+	assert.Equal(t, []string{"finish"}, st.GetPlaces())
+
+	_, ok = st.GetChild("review")
+	assert.False(t, ok)
+}
+
+func TestNet_TransitSubprocess_ChildIsNotStarted_ExpectedErr(t *testing.T) {
+	net := newSubprocessNet(t)
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+
+	err := net.TransitSubprocess(context.Background(), st, []string{"review"}, "approve")
+	assert.Equal(
+		t,
+		state.NewError(state.ErrCodeStateSubprocessIsNotStarted, "Subprocess of transition 'review' is not started"),
+		err,
+	)
+}
+
+func newCancelNet() *Net {
+	return NewNet(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "payment", "shipping", "cancelled", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"order":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"payment", "shipping"}},
+			"cancel": {From: []cfg.StringID{"payment"}, To: []cfg.StringID{"cancelled"}, Cancel: []cfg.StringID{"shipping"}},
+		},
+	})
+}
+
+func TestNet_Transit_CancelRegion_TokensAreCancelled(t *testing.T) {
+	net := newCancelNet()
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+	require.NoError(t, net.Transit(context.Background(), st, "order"))
+
+	require.NoError(t, net.Transit(context.Background(), st, "cancel"))
+	assert.Equal(t, []string{"cancelled"}, st.GetPlaces())
+}
+
+func TestNet_Transit_CancelRegionWithRunningSubprocess_ChildIsRemoved(t *testing.T) {
+	review := cfg.Minimal{
+		Start:       "start",
+		Finish:      "finish",
+		Places:      []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{"approve": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}}},
+	}
+
+	registry := cfg.NewRegistry()
+	require.NoError(t, registry.AddWithName("review", review))
+
+	net := NewNet(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "payment", "shipping", "cancelled", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"order":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"payment", "shipping"}},
+			"ship":   {From: []cfg.StringID{"shipping"}, To: []cfg.StringID{"finish"}, Subprocess: "review"},
+			"cancel": {From: []cfg.StringID{"payment"}, To: []cfg.StringID{"cancelled"}, Cancel: []cfg.StringID{"shipping"}},
+		},
+	})
+	net.WithRegistry(registry)
+
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+	require.NoError(t, net.Transit(context.Background(), st, "order"))
+	require.NoError(t, net.Transit(context.Background(), st, "ship"))
+
+	require.NoError(t, net.Transit(context.Background(), st, "cancel"))
+	assert.Equal(t, []string{"cancelled"}, st.GetPlaces())
+
+	_, ok := st.GetChild("ship")
+	assert.False(t, ok)
+}
+
+func TestNet_Transit_CancelRegionNotCancellableState_ExpectedErr(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	st := NewMockStateInterface(ctrl)
+	st.EXPECT().IsStarted().Return(true)
+
+	err := newCancelNet().Transit(context.Background(), st, "cancel")
+	assert.Equal(
+		t,
+		state.NewError(state.ErrCodeNetStateDoesntSupportCancellation, "State doesn't support cancellation regions"),
+		err,
+	)
+}
+
+func TestNet_Process_StartToOutcomePlace_FinishedWithOutcome(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	config := mocks.NewMockOutcomesInterface(ctrl)
+	config.EXPECT().GetTransitions().Return(cfg.MinimalTransitionRegistry{})
+	config.EXPECT().GetPlaces().Return([]cfg.IDGetter{cfg.StringID("b")})
+	config.EXPECT().GetStart().Return(cfg.StringID("b"))
+	config.EXPECT().GetFinish().Return(cfg.StringID("c"))
+	config.EXPECT().GetOutcomes().Return(map[string]cfg.IDGetter{"rejected": cfg.StringID("b")})
+
+	net := NewNet(config)
+	st := NewMockOutcomeStateInterface(ctrl)
+	st.EXPECT().IsStarted().Return(false)
+	st.EXPECT().MoveTokensFromPlacesToPlaces(startCtx(st), []string{}, []string{"b"}).Return(nil)
+	st.EXPECT().SetFinishedWithOutcome("rejected").Return(nil)
+
+	err := net.Start(context.Background(), st)
+	assert.NoError(t, err)
+}
+
+func TestNet_Transit_Outcomes_StateHasOutcome(t *testing.T) {
+	net := NewNet(cfg.Minimal{
+		Start:    "start",
+		Places:   []cfg.StringID{"start", "approved", "rejected"},
+		Outcomes: map[string]cfg.StringID{"approved": "approved", "rejected": "rejected"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"approve": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"approved"}},
+			"reject":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"rejected"}},
+		},
+	})
+
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+	require.NoError(t, net.Transit(context.Background(), st, "reject"))
+
+	assert.True(t, st.IsFinished())
+	assert.Equal(t, "rejected", st.GetOutcome())
+}
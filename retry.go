@@ -0,0 +1,28 @@
+package gowfnet
+
+import (
+	"context"
+
+	"github.com/andrskom/gowfnet/state"
+)
+
+// ResolvableStateInterface is a state which errors can be resolved, e.g. state.State.
+type ResolvableStateInterface interface {
+	StateInterface
+	ResolveErrors(resolution string)
+}
+
+// Retry resolves all errors of the state with the resolution and transits the state again.
+//
+// Use it for repairing of an instance after a failure, errors stay in the err stack as resolved.
+// If the transition fails, the err is returned and errors stay resolved.
+func (n *Net) Retry(ctx context.Context, s StateInterface, transitionID string, resolution string) error {
+	resolvable, ok := s.(ResolvableStateInterface)
+	if !ok {
+		return state.NewError(state.ErrCodeNetStateDoesntSupportResolving, "State doesn't support resolving of errors")
+	}
+
+	resolvable.ResolveErrors(resolution)
+
+	return n.Transit(ctx, s, transitionID)
+}
@@ -0,0 +1,47 @@
+package gowfnet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/state"
+)
+
+func TestNet_Retry_ErrorsAreResolvedAndTransitionIsDone(t *testing.T) {
+	net := NewNet(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"t": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}},
+		},
+	})
+
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+	st.AddError(errors.New("timeout"))
+	require.True(t, state.ErrorIs(state.ErrCodeStateIsErrorState, net.Transit(context.Background(), st, "t")))
+
+	require.NoError(t, net.Retry(context.Background(), st, "t", "service is repaired"))
+	assert.True(t, st.IsFinished())
+	assert.False(t, st.IsError())
+
+	errs := st.GetErrorStack().GetErrs()
+	require.Len(t, errs, 1)
+	assert.True(t, errs[0].IsResolved())
+	assert.Equal(t, "service is repaired", errs[0].GetResolution())
+}
+
+func TestNet_Retry_NotResolvableState_ExpectedErr(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	err := NewNet(cfg.Minimal{Transitions: cfg.MinimalTransitionRegistry{}}).
+		Retry(context.Background(), NewMockStateInterface(ctrl), "t", "a")
+	assert.Equal(t, state.NewError(state.ErrCodeNetStateDoesntSupportResolving, "State doesn't support resolving of errors"), err)
+}
@@ -0,0 +1,187 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/andrskom/gowfnet"
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/clock"
+	"github.com/andrskom/gowfnet/state"
+)
+
+// StateInterface is a state which knows when its places were marked, e.g. state.Timed.
+type StateInterface interface {
+	gowfnet.StateInterface
+	GetMarkedAt(place string) (time.Time, bool)
+}
+
+// Scheduler fires timed transitions of the net when they are due.
+// It doesn't run in background, call Fire by your ticker or at the moment returned by Next.
+type Scheduler struct {
+	net   *gowfnet.Net
+	clock clock.Clock
+	timed map[string]timedTransition
+}
+
+type timedTransition struct {
+	timer cfg.TimerInterface
+	from  []string
+}
+
+// New init scheduler for timed transitions of the net.
+func New(net *gowfnet.Net, c clock.Clock) *Scheduler {
+	s := &Scheduler{
+		net:   net,
+		clock: c,
+		timed: make(map[string]timedTransition),
+	}
+
+	for id, transition := range net.GetCfg().GetTransitions().GetAsMap() {
+		timedTr, ok := transition.(cfg.TimedTransitionInterface)
+		if !ok || timedTr.GetTimer() == nil {
+			continue
+		}
+
+		from := make([]string, 0, len(transition.GetFrom()))
+		for _, place := range transition.GetFrom() {
+			from = append(from, place.GetID())
+		}
+
+		s.timed[id] = timedTransition{timer: timedTr.GetTimer(), from: from}
+	}
+
+	return s
+}
+
+// DueAt returns the moment when the timed transition becomes due for the state.
+// Returns false if the transition is not timed or it is not enabled in the state.
+func (s *Scheduler) DueAt(st StateInterface, transitionID string) (time.Time, bool) {
+	tr, ok := s.timed[transitionID]
+	if !ok || len(tr.from) == 0 || !st.IsStarted() || st.IsFinished() || st.IsError() {
+		return time.Time{}, false
+	}
+
+	var first, last time.Time
+
+	for i, place := range tr.from {
+		markedAt, ok := st.GetMarkedAt(place)
+		if !ok {
+			return time.Time{}, false
+		}
+
+		if i == 0 || markedAt.Before(first) {
+			first = markedAt
+		}
+
+		if i == 0 || markedAt.After(last) {
+			last = markedAt
+		}
+	}
+
+	switch tr.timer.GetType() {
+	case cfg.TimerTypeDelay:
+		return last.Add(tr.timer.GetDuration()), true
+	case cfg.TimerTypeDeadline:
+		return first.Add(tr.timer.GetDuration()), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// Due returns ids of timed transitions which are due for the state now.
+// Result is sorted by due moment, transitions with the same moment are sorted by id.
+func (s *Scheduler) Due(st StateInterface) []string {
+	now := s.clock.Now()
+	dueAt := make(map[string]time.Time)
+	res := make([]string, 0)
+
+	for id := range s.timed {
+		at, ok := s.DueAt(st, id)
+		if !ok || at.After(now) {
+			continue
+		}
+
+		dueAt[id] = at
+		res = append(res, id)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if dueAt[res[i]].Equal(dueAt[res[j]]) {
+			return res[i] < res[j]
+		}
+
+		return dueAt[res[i]].Before(dueAt[res[j]])
+	})
+
+	return res
+}
+
+// Next returns the nearest moment when one of timed transitions becomes due for one of states.
+// The moment can be in the past if states have due transitions.
+// Returns false if states can't fire any timed transition.
+func (s *Scheduler) Next(states ...StateInterface) (time.Time, bool) {
+	var (
+		res   time.Time
+		found bool
+	)
+
+	for _, st := range states {
+		for id := range s.timed {
+			at, ok := s.DueAt(st, id)
+			if !ok {
+				continue
+			}
+
+			if !found || at.Before(res) {
+				res = at
+				found = true
+			}
+		}
+	}
+
+	return res, found
+}
+
+// Fire due timed transitions of states via Net.Transit.
+// Every transition is fired at most once per state in a call, so zero timers can't loop forever.
+// Errors don't stop processing of other states, they are returned together as *state.ErrStack.
+func (s *Scheduler) Fire(ctx context.Context, states ...StateInterface) error {
+	errStack := state.NewErrStack()
+
+	for _, st := range states {
+		fired := make(map[string]struct{})
+
+		for {
+			transitionID, ok := s.nextDue(st, fired)
+			if !ok {
+				break
+			}
+
+			fired[transitionID] = struct{}{}
+
+			if err := s.net.Transit(ctx, st, transitionID); err != nil {
+				errStack.Add(state.BuildError(err))
+
+				break
+			}
+		}
+	}
+
+	if errStack.HasErrs() {
+		return errStack
+	}
+
+	return nil
+}
+
+func (s *Scheduler) nextDue(st StateInterface, fired map[string]struct{}) (string, bool) {
+	for _, id := range s.Due(st) {
+		if _, ok := fired[id]; !ok {
+			return id, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,192 @@
+// nolint:gochecknoglobals
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet"
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/clock"
+	"github.com/andrskom/gowfnet/state"
+)
+
+var testNow = time.Date(2020, 10, 4, 0, 0, 0, 0, time.UTC)
+
+var approvalCfg = cfg.Minimal{
+	Start:  "start",
+	Finish: "finish",
+	Places: []cfg.StringID{"start", "approval", "docs", "reminded", "escalated", "finish"},
+	Transitions: cfg.MinimalTransitionRegistry{
+		"toApproval": {
+			From: []cfg.StringID{"start"},
+			To:   []cfg.StringID{"approval", "docs"},
+		},
+		"approve": {
+			From: []cfg.StringID{"approval", "docs"},
+			To:   []cfg.StringID{"finish"},
+		},
+		"remind": {
+			From:  []cfg.StringID{"approval"},
+			To:    []cfg.StringID{"reminded"},
+			Timer: &cfg.MinimalTimer{Type: cfg.TimerTypeDelay, Duration: cfg.Duration(24 * time.Hour)},
+		},
+		"escalate": {
+			From:  []cfg.StringID{"reminded"},
+			To:    []cfg.StringID{"escalated"},
+			Timer: &cfg.MinimalTimer{Type: cfg.TimerTypeDelay, Duration: cfg.Duration(24 * time.Hour)},
+		},
+		"close": {
+			From:  []cfg.StringID{"escalated", "docs"},
+			To:    []cfg.StringID{"finish"},
+			Timer: &cfg.MinimalTimer{Type: cfg.TimerTypeDeadline, Duration: cfg.Duration(time.Hour)},
+		},
+	},
+}
+
+func newStartedState(t *testing.T, net *gowfnet.Net, c clock.Clock) *state.Timed {
+	st := state.NewTimed(c)
+
+	require.NoError(t, net.Start(context.Background(), st))
+	require.NoError(t, net.Transit(context.Background(), st, "toApproval"))
+
+	return st
+}
+
+func TestNew_OnlyTimedTransitions(t *testing.T) {
+	s := New(gowfnet.NewNet(approvalCfg), clock.NewSystem())
+
+	assert.Len(t, s.timed, 3)
+	assert.Contains(t, s.timed, "escalate")
+	assert.Contains(t, s.timed, "remind")
+	assert.Contains(t, s.timed, "close")
+}
+
+func TestScheduler_DueAt(t *testing.T) {
+	c := clock.NewFake(testNow)
+	net := gowfnet.NewNet(approvalCfg)
+	s := New(net, c)
+	st := state.NewTimed(c)
+	ctx := context.Background()
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(ctx, []string{}, []string{"docs", "reminded"}))
+
+	c.Add(24 * time.Hour)
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(ctx, []string{"reminded"}, []string{"escalated"}))
+
+	t.Run("delay", func(t *testing.T) {
+		at, ok := s.DueAt(newStartedState(t, net, c), "remind")
+		assert.True(t, ok)
+		assert.Equal(t, testNow.Add(48*time.Hour), at)
+	})
+	t.Run("not enabled", func(t *testing.T) {
+		_, ok := s.DueAt(st, "escalate")
+		assert.False(t, ok)
+	})
+	t.Run("deadline is counted from the first marked place", func(t *testing.T) {
+		at, ok := s.DueAt(st, "close")
+		assert.True(t, ok)
+		assert.Equal(t, testNow.Add(time.Hour), at)
+	})
+	t.Run("not timed", func(t *testing.T) {
+		_, ok := s.DueAt(st, "approve")
+		assert.False(t, ok)
+	})
+	t.Run("not started", func(t *testing.T) {
+		_, ok := s.DueAt(state.NewTimed(c), "remind")
+		assert.False(t, ok)
+	})
+}
+
+func TestScheduler_Due(t *testing.T) {
+	c := clock.NewFake(testNow)
+	net := gowfnet.NewNet(approvalCfg)
+	s := New(net, c)
+	st := state.NewTimed(c)
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"approval"}))
+
+	c.Add(time.Hour)
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"reminded"}))
+
+	assert.Empty(t, s.Due(st))
+
+	c.Add(48 * time.Hour)
+	assert.Equal(t, []string{"remind", "escalate"}, s.Due(st))
+}
+
+func TestScheduler_Next(t *testing.T) {
+	c := clock.NewFake(testNow)
+	net := gowfnet.NewNet(approvalCfg)
+	s := New(net, c)
+
+	first := newStartedState(t, net, c)
+
+	c.Add(time.Hour)
+
+	second := newStartedState(t, net, c)
+
+	at, ok := s.Next(second, first)
+	assert.True(t, ok)
+	assert.Equal(t, testNow.Add(24*time.Hour), at)
+
+	_, ok = s.Next(state.NewTimed(c))
+	assert.False(t, ok)
+}
+
+func TestScheduler_Fire_DueTransitions_Fired(t *testing.T) {
+	c := clock.NewFake(testNow)
+	net := gowfnet.NewNet(approvalCfg)
+	s := New(net, c)
+	st := newStartedState(t, net, c)
+
+	c.Add(24 * time.Hour)
+	require.NoError(t, s.Fire(context.Background(), st))
+	assert.ElementsMatch(t, []string{"reminded", "docs"}, st.GetPlaces())
+
+	c.Add(24 * time.Hour)
+	require.NoError(t, s.Fire(context.Background(), st))
+	assert.True(t, st.IsFinished())
+	assert.Equal(t, []string{"finish"}, st.GetPlaces())
+}
+
+func TestScheduler_Fire_NothingIsDue_StateIsNotChanged(t *testing.T) {
+	c := clock.NewFake(testNow)
+	net := gowfnet.NewNet(approvalCfg)
+	s := New(net, c)
+	st := newStartedState(t, net, c)
+
+	require.NoError(t, s.Fire(context.Background(), st))
+	assert.ElementsMatch(t, []string{"approval", "docs"}, st.GetPlaces())
+}
+
+func TestScheduler_Fire_TransitErr_ReturnsErrStack(t *testing.T) {
+	c := clock.NewFake(testNow)
+	net := gowfnet.NewNet(approvalCfg)
+	net.WithListener(&failingListener{StubListener: gowfnet.NewStubListener()})
+
+	s := New(net, c)
+	st := state.NewTimed(c)
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"approval"}))
+
+	c.Add(24 * time.Hour)
+
+	err := s.Fire(context.Background(), st)
+	require.IsType(t, &state.ErrStack{}, err)
+	assert.Len(t, err.(*state.ErrStack).GetErrs(), 1)
+	assert.Equal(t, "transition is forbidden", err.(*state.ErrStack).GetErrs()[0].GetMessage())
+}
+
+type failingListener struct {
+	*gowfnet.StubListener
+}
+
+func (l *failingListener) BeforeTransition(
+	ctx context.Context,
+	transitionID string,
+	st gowfnet.StateOpInterface,
+) error {
+	return state.NewError(state.ErrCodeUnknown, "transition is forbidden")
+}
@@ -0,0 +1,294 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+const (
+	ErrCodeStatePayloadPlaceIsNotOutput = "gowfnet.state.payloadPlaceIsNotOutput"
+)
+
+// PayloadMapper maps payloads of input places of a transition to payloads of its output places.
+// Keys of maps are place ids. Input contains only places which have a payload.
+type PayloadMapper[T any] func(ctx context.Context, in map[string]T) (map[string]T, error)
+
+// PayloadMappers is a registry of payload mappers by transition id.
+// Usually it is one for a net and shared between all colored states of the net.
+type PayloadMappers[T any] struct {
+	data map[string]PayloadMapper[T]
+}
+
+// NewPayloadMappers init empty registry.
+func NewPayloadMappers[T any]() *PayloadMappers[T] {
+	return &PayloadMappers[T]{data: make(map[string]PayloadMapper[T])}
+}
+
+// Add mapper for transition, the previous mapper for the transition is replaced.
+func (m *PayloadMappers[T]) Add(transitionID string, mapper PayloadMapper[T]) {
+	m.data[transitionID] = mapper
+}
+
+// Get mapper for transition, returns false if it was not added.
+func (m *PayloadMappers[T]) Get(transitionID string) (PayloadMapper[T], bool) {
+	res, ok := m.data[transitionID]
+
+	return res, ok
+}
+
+// PayloadCodec serializes payloads of colored state.
+// Encode must return valid json.
+type PayloadCodec[T any] interface {
+	Encode(payload T) ([]byte, error)
+	Decode(data []byte) (T, error)
+}
+
+// JSONPayloadCodec is a codec based on encoding/json.
+type JSONPayloadCodec[T any] struct{}
+
+// NewJSONPayloadCodec init json codec.
+func NewJSONPayloadCodec[T any]() *JSONPayloadCodec[T] {
+	return &JSONPayloadCodec[T]{}
+}
+
+func (c *JSONPayloadCodec[T]) Encode(payload T) ([]byte, error) {
+	return json.Marshal(payload)
+}
+
+func (c *JSONPayloadCodec[T]) Decode(data []byte) (T, error) {
+	var res T
+
+	err := json.Unmarshal(data, &res)
+
+	return res, err
+}
+
+// Colored is a state where each token can carry a payload.
+//
+// On transition payloads of output places are built by the mapper registered for the transition.
+// If there is no mapper and input places have exactly one payload, it is copied to every output place.
+// Otherwise output places are without payloads.
+// Use SetPayload for setting payload of the start place.
+type Colored[T any] struct {
+	*State
+	mappers  *PayloadMappers[T]
+	codec    PayloadCodec[T]
+	payloads map[string]T
+	mu       sync.Mutex
+}
+
+// NewColored init new colored state.
+func NewColored[T any](mappers *PayloadMappers[T], codec PayloadCodec[T]) *Colored[T] {
+	return &Colored[T]{
+		State:    NewState(),
+		mappers:  mappers,
+		codec:    codec,
+		payloads: make(map[string]T),
+	}
+}
+
+// GetPayload of the token in the place, returns false if the token doesn't have payload.
+func (c *Colored[T]) GetPayload(place string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res, ok := c.payloads[place]
+
+	return res, ok
+}
+
+// SetPayload of the token in the place.
+func (c *Colored[T]) SetPayload(place string, payload T) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.State.places[place]; !ok {
+		return NewErrorf(ErrCodeStateHasNotTokenInPlace, "Can't set payload, state has not token in place '%s'", place)
+	}
+
+	c.payloads[place] = payload
+
+	return nil
+}
+
+// MoveTokensFromPlacesToPlaces for create new state and map payloads of tokens.
+// If the mapper returns err, tokens are not moved.
+func (c *Colored[T]) MoveTokensFromPlacesToPlaces(ctx context.Context, from []string, to []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out, err := c.mapPayloads(ctx, from, to)
+	if err != nil {
+		return err
+	}
+
+	if err := c.State.MoveTokensFromPlacesToPlaces(ctx, from, to); err != nil {
+		return err
+	}
+
+	for _, place := range from {
+		delete(c.payloads, place)
+	}
+
+	for place, payload := range out {
+		c.payloads[place] = payload
+	}
+
+	return nil
+}
+
+// CancelTokens removes tokens with their payloads from places.
+func (c *Colored[T]) CancelTokens(ctx context.Context, places []string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cancelled, err := c.State.CancelTokens(ctx, places)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, place := range cancelled {
+		delete(c.payloads, place)
+	}
+
+	return cancelled, nil
+}
+
+// RevertLast reverts the last transition and removes payloads of its output places.
+// Restored places are without payloads, use SetPayload if you need them.
+func (c *Colored[T]) RevertLast(ctx context.Context) (HistoryEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, err := c.State.RevertLast(ctx)
+	if err != nil {
+		return entry, err
+	}
+
+	for _, place := range entry.To {
+		delete(c.payloads, place)
+	}
+
+	return entry, nil
+}
+
+func (c *Colored[T]) mapPayloads(ctx context.Context, from []string, to []string) (map[string]T, error) {
+	in := make(map[string]T)
+
+	for _, place := range from {
+		if payload, ok := c.payloads[place]; ok {
+			in[place] = payload
+		}
+	}
+
+	transitionID, ok := GetTransitionID(ctx)
+	if ok && c.mappers != nil {
+		if mapper, ok := c.mappers.Get(transitionID); ok {
+			out, err := mapper(ctx, in)
+			if err != nil {
+				return nil, err
+			}
+
+			return out, checkPayloadPlaces(out, to)
+		}
+	}
+
+	out := make(map[string]T)
+
+	if len(in) != 1 {
+		return out, nil
+	}
+
+	for _, payload := range in {
+		for _, place := range to {
+			out[place] = payload
+		}
+	}
+
+	return out, nil
+}
+
+func checkPayloadPlaces[T any](payloads map[string]T, to []string) error {
+	toMap := make(map[string]struct{}, len(to))
+	for _, place := range to {
+		toMap[place] = struct{}{}
+	}
+
+	for place := range payloads {
+		if _, ok := toMap[place]; !ok {
+			return NewErrorf(
+				ErrCodeStatePayloadPlaceIsNotOutput,
+				"Mapper returns payload for place '%s' which is not output of transition",
+				place,
+			)
+		}
+	}
+
+	return nil
+}
+
+type jsonColoredState struct {
+	jsonState
+	Payloads map[string]json.RawMessage `json:"payloads"`
+}
+
+func (c *Colored[T]) MarshalJSON() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	payloads := make(map[string]json.RawMessage, len(c.payloads))
+
+	for place, payload := range c.payloads {
+		data, err := c.getCodec().Encode(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		payloads[place] = data
+	}
+
+	return json.Marshal(jsonColoredState{
+		jsonState: c.State.toJSON(),
+		Payloads:  payloads,
+	})
+}
+
+func (c *Colored[T]) UnmarshalJSON(data []byte) error {
+	var jsonSt jsonColoredState
+
+	if err := json.Unmarshal(data, &jsonSt); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	payloads := make(map[string]T, len(jsonSt.Payloads))
+
+	for place, data := range jsonSt.Payloads {
+		payload, err := c.getCodec().Decode(data)
+		if err != nil {
+			return err
+		}
+
+		payloads[place] = payload
+	}
+
+	if c.State == nil {
+		c.State = NewState()
+	}
+
+	c.State.fromJSON(jsonSt.jsonState)
+	c.payloads = payloads
+
+	return nil
+}
+
+func (c *Colored[T]) getCodec() PayloadCodec[T] {
+	if c.codec == nil {
+		c.codec = NewJSONPayloadCodec[T]()
+	}
+
+	return c.codec
+}
@@ -0,0 +1,235 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testingDocument struct {
+	Reviewer string `json:"reviewer"`
+}
+
+func newStartedColored(t *testing.T, mappers *PayloadMappers[testingDocument]) *Colored[testingDocument] {
+	st := NewColored[testingDocument](mappers, NewJSONPayloadCodec[testingDocument]())
+
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"a"}))
+	require.NoError(t, st.SetPayload("a", testingDocument{Reviewer: "bob"}))
+
+	return st
+}
+
+func TestPayloadMappers(t *testing.T) {
+	mappers := NewPayloadMappers[int]()
+
+	_, ok := mappers.Get("a")
+	assert.False(t, ok)
+
+	mappers.Add("a", func(ctx context.Context, in map[string]int) (map[string]int, error) {
+		return nil, nil
+	})
+
+	_, ok = mappers.Get("a")
+	assert.True(t, ok)
+}
+
+func TestColored_SetPayload_NoToken_ExpectedErr(t *testing.T) {
+	st := NewColored[int](NewPayloadMappers[int](), NewJSONPayloadCodec[int]())
+
+	err := st.SetPayload("a", 1)
+	assert.True(t, ErrorIs(ErrCodeStateHasNotTokenInPlace, err))
+
+	_, ok := st.GetPayload("a")
+	assert.False(t, ok)
+}
+
+func TestColored_MoveTokensFromPlacesToPlaces_WithoutMapper_PayloadIsCopied(t *testing.T) {
+	st := newStartedColored(t, NewPayloadMappers[testingDocument]())
+
+	ctx := WithTransitionID(context.Background(), "t")
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(ctx, []string{"a"}, []string{"b", "c"}))
+
+	_, ok := st.GetPayload("a")
+	assert.False(t, ok)
+
+	for _, place := range []string{"b", "c"} {
+		payload, ok := st.GetPayload(place)
+		assert.True(t, ok)
+		assert.Equal(t, testingDocument{Reviewer: "bob"}, payload)
+	}
+}
+
+func TestColored_MoveTokensFromPlacesToPlaces_WithoutMapperManyPayloads_NoPayloads(t *testing.T) {
+	st := newStartedColored(t, NewPayloadMappers[testingDocument]())
+
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"b"}))
+	require.NoError(t, st.SetPayload("b", testingDocument{Reviewer: "alice"}))
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{"a", "b"}, []string{"c"}))
+
+	_, ok := st.GetPayload("c")
+	assert.False(t, ok)
+}
+
+func TestColored_MoveTokensFromPlacesToPlaces_WithMapper_PayloadsAreMapped(t *testing.T) {
+	mappers := NewPayloadMappers[testingDocument]()
+	mappers.Add("t", func(ctx context.Context, in map[string]testingDocument) (map[string]testingDocument, error) {
+		return map[string]testingDocument{
+			"b": {Reviewer: strings.ToUpper(in["a"].Reviewer)},
+		}, nil
+	})
+
+	st := newStartedColored(t, mappers)
+
+	ctx := WithTransitionID(context.Background(), "t")
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(ctx, []string{"a"}, []string{"b", "c"}))
+
+	payload, ok := st.GetPayload("b")
+	assert.True(t, ok)
+	assert.Equal(t, testingDocument{Reviewer: "BOB"}, payload)
+
+	_, ok = st.GetPayload("c")
+	assert.False(t, ok)
+}
+
+func TestColored_MoveTokensFromPlacesToPlaces_MapperErr_TokensAreNotMoved(t *testing.T) {
+	eErr := errors.New("a")
+	mappers := NewPayloadMappers[testingDocument]()
+	mappers.Add("t", func(ctx context.Context, in map[string]testingDocument) (map[string]testingDocument, error) {
+		return nil, eErr
+	})
+
+	st := newStartedColored(t, mappers)
+
+	ctx := WithTransitionID(context.Background(), "t")
+	assert.Same(t, eErr, st.MoveTokensFromPlacesToPlaces(ctx, []string{"a"}, []string{"b"}))
+	assert.Equal(t, []string{"a"}, st.GetPlaces())
+
+	_, ok := st.GetPayload("a")
+	assert.True(t, ok)
+}
+
+func TestColored_MoveTokensFromPlacesToPlaces_MapperReturnsNotOutputPlace_ExpectedErr(t *testing.T) {
+	mappers := NewPayloadMappers[testingDocument]()
+	mappers.Add("t", func(ctx context.Context, in map[string]testingDocument) (map[string]testingDocument, error) {
+		return map[string]testingDocument{"d": {}}, nil
+	})
+
+	st := newStartedColored(t, mappers)
+
+	ctx := WithTransitionID(context.Background(), "t")
+	err := st.MoveTokensFromPlacesToPlaces(ctx, []string{"a"}, []string{"b"})
+	assert.Equal(
+		t,
+		NewError(
+			ErrCodeStatePayloadPlaceIsNotOutput,
+			"Mapper returns payload for place 'd' which is not output of transition",
+		),
+		err,
+	)
+	assert.Equal(t, []string{"a"}, st.GetPlaces())
+}
+
+func TestColored_MoveTokensFromPlacesToPlaces_MoveErr_PayloadsAreNotChanged(t *testing.T) {
+	st := newStartedColored(t, NewPayloadMappers[testingDocument]())
+
+	err := st.MoveTokensFromPlacesToPlaces(context.Background(), []string{"a", "b"}, []string{"c"})
+	assert.True(t, ErrorIs(ErrCodeStateHasNotTokenInPlace, err))
+
+	_, ok := st.GetPayload("c")
+	assert.False(t, ok)
+}
+
+func TestColored_CancelTokens_PayloadIsRemoved(t *testing.T) {
+	st := newStartedColored(t, nil)
+
+	cancelled, err := st.CancelTokens(context.Background(), []string{"a"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, cancelled)
+
+	_, ok := st.GetPayload("a")
+	assert.False(t, ok)
+}
+
+func TestColored_CancelTokens_ErrState_PayloadIsKept(t *testing.T) {
+	st := newStartedColored(t, nil)
+	st.AddError(errors.New("a"))
+
+	_, err := st.CancelTokens(context.Background(), []string{"a"})
+	assert.True(t, ErrorIs(ErrCodeStateIsErrorState, err))
+
+	_, ok := st.GetPayload("a")
+	assert.True(t, ok)
+}
+
+func TestColored_RevertLast_PayloadsOfOutputsAreRemoved(t *testing.T) {
+	st := newStartedColored(t, nil)
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(WithTransitionID(context.Background(), "t"), []string{"a"}, []string{"b"}))
+
+	_, err := st.RevertLast(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, st.GetPlaces())
+
+	_, ok := st.GetPayload("b")
+	assert.False(t, ok)
+}
+
+func TestColored_Serialization(t *testing.T) {
+	st := newStartedColored(t, NewPayloadMappers[testingDocument]())
+
+	bytes, err := json.Marshal(st)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`{"places":["a"],"errStack":{"stack":[]},"isFinished":false,"payloads":{"a":{"reviewer":"bob"}}}`,
+		string(bytes),
+	)
+
+	var newSt Colored[testingDocument]
+
+	require.NoError(t, json.Unmarshal(bytes, &newSt))
+	assert.Equal(t, []string{"a"}, newSt.GetPlaces())
+
+	payload, ok := newSt.GetPayload("a")
+	assert.True(t, ok)
+	assert.Equal(t, testingDocument{Reviewer: "bob"}, payload)
+}
+
+type failingCodec struct {
+	err error
+}
+
+func (c *failingCodec) Encode(payload int) ([]byte, error) {
+	return nil, c.err
+}
+
+func (c *failingCodec) Decode(data []byte) (int, error) {
+	return 0, c.err
+}
+
+func TestColored_MarshalJSON_CodecErr_ExpectedErr(t *testing.T) {
+	eErr := errors.New("a")
+	st := NewColored[int](NewPayloadMappers[int](), &failingCodec{err: eErr})
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"a"}))
+	require.NoError(t, st.SetPayload("a", 1))
+
+	_, err := st.MarshalJSON()
+	assert.Same(t, eErr, err)
+}
+
+func TestColored_UnmarshalJSON_CodecErr_ExpectedErr(t *testing.T) {
+	eErr := errors.New("a")
+	st := NewColored[int](NewPayloadMappers[int](), &failingCodec{err: eErr})
+
+	assert.Same(t, eErr, st.UnmarshalJSON([]byte(`{"places":["a"],"payloads":{"a":1}}`)))
+}
+
+func TestColored_UnmarshalJSON_UnexpectedJSON_ExpectedErr(t *testing.T) {
+	var st Colored[int]
+
+	assert.IsType(t, &json.UnmarshalTypeError{}, json.Unmarshal([]byte("[]"), &st))
+}
@@ -0,0 +1,37 @@
+package state
+
+import "context"
+
+// ctxKey is a type of keys of ctx values of the pkg.
+// Pointers to zero-size vars can be equal, so they must not be used as keys.
+type ctxKey int
+
+const (
+	ctxTransitionID ctxKey = iota
+	ctxErrorCause
+)
+
+// WithTransitionID returns ctx with id of the transition which is processed by the net.
+// The net sets it, so a state and listeners can know which transition moves tokens.
+func WithTransitionID(ctx context.Context, transitionID string) context.Context {
+	return context.WithValue(ctx, ctxTransitionID, transitionID)
+}
+
+// GetTransitionID from ctx, returns false if tokens are moved not by a transition, e.g. on start.
+func GetTransitionID(ctx context.Context) (string, bool) {
+	transitionID, ok := ctx.Value(ctxTransitionID).(string)
+
+	return transitionID, ok
+}
+
+// WithErrorCause returns ctx with the failure of the transition which tokens are moved to the error place because of.
+func WithErrorCause(ctx context.Context, cause error) context.Context {
+	return context.WithValue(ctx, ctxErrorCause, cause)
+}
+
+// GetErrorCause from ctx, returns false if tokens are moved not to the error place.
+func GetErrorCause(ctx context.Context) (error, bool) {
+	cause, ok := ctx.Value(ctxErrorCause).(error)
+
+	return cause, ok
+}
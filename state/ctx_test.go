@@ -0,0 +1,48 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCtxTransitionID(t *testing.T) {
+	transitionID, ok := GetTransitionID(WithTransitionID(context.Background(), "a"))
+	assert.True(t, ok)
+	assert.Equal(t, "a", transitionID)
+}
+
+func TestGetTransitionID_NotSet_ReturnsNotOk(t *testing.T) {
+	transitionID, ok := GetTransitionID(context.Background())
+	assert.False(t, ok)
+	assert.Empty(t, transitionID)
+}
+
+func TestCtxErrorCause(t *testing.T) {
+	eErr := errors.New("a")
+
+	cause, ok := GetErrorCause(WithErrorCause(context.Background(), eErr))
+	assert.True(t, ok)
+	assert.Same(t, eErr, cause)
+}
+
+func TestGetErrorCause_NotSet_ReturnsNotOk(t *testing.T) {
+	cause, ok := GetErrorCause(context.Background())
+	assert.False(t, ok)
+	assert.Nil(t, cause)
+}
+
+func TestGetTransitionID_WithErrorCause_TransitionIDIsNotOverwritten(t *testing.T) {
+	eErr := errors.New("a")
+	ctx := WithErrorCause(WithTransitionID(context.Background(), "t"), eErr)
+
+	transitionID, ok := GetTransitionID(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "t", transitionID)
+
+	cause, ok := GetErrorCause(ctx)
+	assert.True(t, ok)
+	assert.Same(t, eErr, cause)
+}
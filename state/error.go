@@ -2,24 +2,39 @@ package state
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
 type ErrCode string
 
 const (
-	ErrCodeUnknown                      = "gowfnet.unknown"
-	ErrCodeStateHasNotTokenInPlace      = "gowfnet.state.HasNotTokenInPlace"     // nolint:gosec
-	ErrCodeStateAlreadyHasTokenInPlace  = "gowfnet.state.AlreadyHasTokenInPlace" // nolint:gosec
-	ErrCodeStateAlreadyStarted          = "gowfnet.state.alreadyStarted"
-	ErrCodeStateIsNotStarted            = "gowfnet.state.isNotStarted"
-	ErrCodeStateIsFinished              = "gowfnet.state.isFinished"
-	ErrCodeStateIsAlreadyFinished       = "gowfnet.state.isAlreadyFinished"
-	ErrCodeStateIsErrorState            = "gowfnet.state.isErrorState"
-	ErrCodeNetDoesntKnowAboutTransition = "gowfnet.netDoesntKnowAboutTransition"
-	ErrCodeNetDoesntKnowAboutPlace      = "gowfnet.netDoesntKnowAboutPlace"
-	ErrCodeRegistryNetAlreadyRegistered = "gowfnet.registryNetAlreadyRegistered"
-	ErrCodeRegistryNetNotRegistered     = "gowfnet.registryNetNotRegistered"
+	ErrCodeUnknown                           = "gowfnet.unknown"
+	ErrCodeStateHasNotTokenInPlace           = "gowfnet.state.HasNotTokenInPlace"     // nolint:gosec
+	ErrCodeStateAlreadyHasTokenInPlace       = "gowfnet.state.AlreadyHasTokenInPlace" // nolint:gosec
+	ErrCodeStateAlreadyStarted               = "gowfnet.state.alreadyStarted"
+	ErrCodeStateIsNotStarted                 = "gowfnet.state.isNotStarted"
+	ErrCodeStateIsFinished                   = "gowfnet.state.isFinished"
+	ErrCodeStateIsAlreadyFinished            = "gowfnet.state.isAlreadyFinished"
+	ErrCodeStateIsErrorState                 = "gowfnet.state.isErrorState"
+	ErrCodeStateSubprocessAlreadyStarted     = "gowfnet.state.subprocessAlreadyStarted"
+	ErrCodeStateSubprocessIsNotStarted       = "gowfnet.state.subprocessIsNotStarted"
+	ErrCodeStateErrorIsNotFound              = "gowfnet.state.errorIsNotFound"
+	ErrCodeStateErrorIsAlreadyResolved       = "gowfnet.state.errorIsAlreadyResolved"
+	ErrCodeStateHasNotHistory                = "gowfnet.state.hasNotHistory"
+	ErrCodeStateHasTokensAfterCompletion     = "gowfnet.state.hasTokensAfterCompletion"
+	ErrCodeNetDoesntKnowAboutTransition      = "gowfnet.netDoesntKnowAboutTransition"
+	ErrCodeNetDoesntKnowAboutPlace           = "gowfnet.netDoesntKnowAboutPlace"
+	ErrCodeRegistryNetAlreadyRegistered      = "gowfnet.registryNetAlreadyRegistered"
+	ErrCodeRegistryNetNotRegistered          = "gowfnet.registryNetNotRegistered"
+	ErrCodeNetHasNotRegistry                 = "gowfnet.netHasNotRegistry"
+	ErrCodeNetStateDoesntSupportNesting      = "gowfnet.netStateDoesntSupportNesting"
+	ErrCodeNetStateDoesntSupportHistory      = "gowfnet.netStateDoesntSupportHistory"
+	ErrCodeNetStateDoesntSupportResolving    = "gowfnet.netStateDoesntSupportResolving"
+	ErrCodeNetTransitionIsRoutedToErrorPlace = "gowfnet.netTransitionIsRoutedToErrorPlace"
+	ErrCodeNetTransitionIsNotReversible      = "gowfnet.netTransitionIsNotReversible"
+	ErrCodeNetStateDoesntSupportCancellation = "gowfnet.netStateDoesntSupportCancellation"
+	ErrCodeNetPlaceIsReservedBySubprocess    = "gowfnet.netPlaceIsReservedBySubprocess"
 )
 
 // ErrStack is a stack of errors for state.
@@ -54,6 +69,43 @@ func (s *ErrStack) GetErrs() []Error {
 	return s.stack
 }
 
+// HasUnresolvedErrs in stack.
+func (s *ErrStack) HasUnresolvedErrs() bool {
+	for i := range s.stack {
+		if !s.stack[i].IsResolved() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Resolve err by index in stack with the reason of resolution.
+func (s *ErrStack) Resolve(index int, resolution string) error {
+	if index < 0 || index >= len(s.stack) {
+		return NewErrorf(ErrCodeStateErrorIsNotFound, "Error with index %d is not found in stack", index)
+	}
+
+	if s.stack[index].IsResolved() {
+		return NewErrorf(ErrCodeStateErrorIsAlreadyResolved, "Error with index %d is already resolved", index)
+	}
+
+	s.stack[index].resolved = true
+	s.stack[index].resolution = resolution
+
+	return nil
+}
+
+// ResolveAll unresolved errs in stack with the reason of resolution.
+func (s *ErrStack) ResolveAll(resolution string) {
+	for i := range s.stack {
+		if !s.stack[i].IsResolved() {
+			s.stack[i].resolved = true
+			s.stack[i].resolution = resolution
+		}
+	}
+}
+
 // Error interface implementation.
 func (s *ErrStack) Error() string {
 	res := ""
@@ -85,10 +137,26 @@ func (s ErrStack) MarshalJSON() ([]byte, error) {
 	return json.Marshal(jsonErrStack{Stack: s.stack})
 }
 
+// Unwrap returns errs of stack, so errors.Is and errors.As check each of them.
+func (s *ErrStack) Unwrap() []error {
+	res := make([]error, 0, len(s.stack))
+
+	for i := range s.stack {
+		res = append(res, &s.stack[i])
+	}
+
+	return res
+}
+
 // Error is err model of component.
+// Error in the stack of state can be resolved, resolved errors don't make the state error state.
+// Error can wrap the underlying cause, it is available by errors.Unwrap and is kept in json.
 type Error struct {
-	code    ErrCode
-	message string
+	code       ErrCode
+	message    string
+	cause      error
+	resolved   bool
+	resolution string
 }
 
 // NewError init errStack.
@@ -104,6 +172,20 @@ func NewErrorf(errorCode ErrCode, format string, args ...interface{}) *Error {
 	return NewError(errorCode, fmt.Sprintf(format, args...))
 }
 
+// Wrap the cause into err with the code, the message of the cause is appended to msg.
+func Wrap(errorCode ErrCode, cause error, msg string) *Error {
+	return &Error{
+		code:    errorCode,
+		message: msg + ": " + cause.Error(),
+		cause:   cause,
+	}
+}
+
+// Wrapf the cause into err with the code by format.
+func Wrapf(errorCode ErrCode, cause error, format string, args ...interface{}) *Error {
+	return Wrap(errorCode, cause, fmt.Sprintf(format, args...))
+}
+
 // ErrorIs check errStack.
 func (e *Error) GetCode() ErrCode {
 	return e.code
@@ -114,33 +196,57 @@ func (e *Error) GetMessage() string {
 	return e.message
 }
 
-// ErrorIs check errStack.
-func (e *Error) Is(errorCode ErrCode) bool {
+// IsCode check code of err.
+// It was Is(ErrCode) in v1, the name Is is used by errors.Is now.
+func (e *Error) IsCode(errorCode ErrCode) bool {
 	return e.code == errorCode
 }
 
+// Is reports whether the target is *Error with the same code, e.g. a sentinel.
+// It is used by errors.Is.
+func (e *Error) Is(target error) bool {
+	var targetErr *Error
+	if !errors.As(target, &targetErr) {
+		return false
+	}
+
+	return e.code == targetErr.code
+}
+
+// Unwrap returns the cause of err or nil.
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// IsResolved returns true if the err is resolved.
+func (e *Error) IsResolved() bool {
+	return e.resolved
+}
+
+// GetResolution returns the reason of resolution.
+func (e *Error) GetResolution() string {
+	return e.resolution
+}
+
 func (e *Error) Error() string {
 	return e.message
 }
 
-// ErrorIs compare err and errStack code. If err is not nil, is *Error type and have the same code or false.
+// ErrorIs compare err and errStack code.
+// Returns true if err or any err in its chain, e.g. wrapped by fmt.Errorf or in ErrStack, is *Error with the code.
 func ErrorIs(code ErrCode, err error) bool {
 	if err == nil {
 		return false
 	}
 
-	errModel, ok := err.(*Error)
-	if !ok {
-		return false
-	}
-
-	return errModel.Is(code)
+	return errors.Is(err, &Error{code: code})
 }
 
 // BuildError from errStack interface.
 // If arg contains nil, return nil.
 // If arg contains Error type, return that.
-// If arg contains another type, build Error with unknown code.
+// If arg wraps Error type, build Error with its code which wraps arg.
+// If arg contains another type, build Error with unknown code which wraps arg.
 func BuildError(err error) *Error {
 	if err == nil {
 		return nil
@@ -151,23 +257,47 @@ func BuildError(err error) *Error {
 		return res
 	}
 
-	return NewError(ErrCodeUnknown, err.Error())
+	if errors.As(err, &res) {
+		return &Error{code: res.code, message: err.Error(), cause: err}
+	}
+
+	return &Error{code: ErrCodeUnknown, message: err.Error(), cause: err}
 }
 
 type jsonErr struct {
-	Code    ErrCode `json:"code"`
-	Message string  `json:"message"`
+	Code       ErrCode `json:"code"`
+	Message    string  `json:"message"`
+	Cause      *Error  `json:"cause,omitempty"`
+	Resolved   bool    `json:"resolved,omitempty"`
+	Resolution string  `json:"resolution,omitempty"`
 }
 
 func (e Error) MarshalJSON() ([]byte, error) {
 	jsonSt := jsonErr{
-		Code:    e.code,
-		Message: e.message,
+		Code:       e.code,
+		Message:    e.message,
+		Cause:      buildJSONCause(e.cause),
+		Resolved:   e.resolved,
+		Resolution: e.resolution,
 	}
 
 	return json.Marshal(jsonSt)
 }
 
+// buildJSONCause converts the cause to *Error for serialization.
+// A cause of another type is kept as *Error with empty code, it is restored by errors.New.
+func buildJSONCause(cause error) *Error {
+	if cause == nil {
+		return nil
+	}
+
+	if res, ok := cause.(*Error); ok {
+		return res
+	}
+
+	return &Error{message: cause.Error()}
+}
+
 func (e *Error) UnmarshalJSON(data []byte) error {
 	var jsonErr jsonErr
 
@@ -177,6 +307,17 @@ func (e *Error) UnmarshalJSON(data []byte) error {
 
 	e.code = jsonErr.Code
 	e.message = jsonErr.Message
+	e.cause = nil
+
+	if jsonErr.Cause != nil {
+		e.cause = jsonErr.Cause
+		if jsonErr.Cause.code == "" {
+			e.cause = errors.New(jsonErr.Cause.message)
+		}
+	}
+
+	e.resolved = jsonErr.Resolved
+	e.resolution = jsonErr.Resolution
 
 	return nil
 }
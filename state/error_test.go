@@ -47,15 +47,50 @@ func TestNewErrorf(t *testing.T) {
 	})
 }
 
-func TestError_Is(t *testing.T) {
+func TestError_IsCode(t *testing.T) {
 	t.Run("not is errStack", func(t *testing.T) {
-		require.False(t, NewErrorf(ErrCodeUnknown, "a").Is(ErrCodeNetDoesntKnowAboutPlace))
+		require.False(t, NewErrorf(ErrCodeUnknown, "a").IsCode(ErrCodeNetDoesntKnowAboutPlace))
 	})
 	t.Run("is errStack", func(t *testing.T) {
-		require.True(t, NewErrorf(ErrCodeUnknown, "a").Is(ErrCodeUnknown))
+		require.True(t, NewErrorf(ErrCodeUnknown, "a").IsCode(ErrCodeUnknown))
+	})
+}
+
+func TestError_Is(t *testing.T) {
+	t.Run("sentinel", func(t *testing.T) {
+		require.True(t, errors.Is(NewError(ErrCodeStateIsFinished, "a"), ErrStateIsFinished))
+	})
+	t.Run("another sentinel", func(t *testing.T) {
+		require.False(t, errors.Is(NewError(ErrCodeStateIsFinished, "a"), ErrStateIsErrorState))
+	})
+	t.Run("wrapped", func(t *testing.T) {
+		err := fmt.Errorf("b: %w", NewError(ErrCodeStateIsFinished, "a"))
+		require.True(t, errors.Is(err, ErrStateIsFinished))
+	})
+	t.Run("not Error type", func(t *testing.T) {
+		require.False(t, NewError(ErrCodeUnknown, "a").Is(errors.New("a")))
 	})
 }
 
+func TestWrap(t *testing.T) {
+	cause := errors.New("b")
+	err := Wrapf(testingErrCode1, cause, "a %d", 1)
+
+	assert.Equal(t, "a 1: b", err.Error())
+	assert.Equal(t, testingErrCode1, err.GetCode())
+	assert.Same(t, cause, errors.Unwrap(err))
+	assert.True(t, errors.Is(err, cause))
+}
+
+func TestError_As(t *testing.T) {
+	err := fmt.Errorf("b: %w", NewError(testingErrCode1, "a"))
+
+	var stateErr *Error
+
+	require.True(t, errors.As(err, &stateErr))
+	assert.Equal(t, testingErrCode1, stateErr.GetCode())
+}
+
 func TestError_GetCode(t *testing.T) {
 	err := NewError(testingErrCode1, "a")
 
@@ -89,6 +124,16 @@ func TestErrorIs(t *testing.T) {
 	t.Run("expected code", func(t *testing.T) {
 		require.True(t, ErrorIs(ErrCodeUnknown, NewError(ErrCodeUnknown, "a")))
 	})
+	t.Run("wrapped expected code", func(t *testing.T) {
+		require.True(t, ErrorIs(ErrCodeUnknown, fmt.Errorf("b: %w", NewError(ErrCodeUnknown, "a"))))
+	})
+	t.Run("expected code in stack", func(t *testing.T) {
+		stack := NewErrStack()
+		stack.Add(NewError(testingErrCode1, "a"))
+		stack.Add(NewError(testingErrCode2, "b"))
+
+		require.True(t, ErrorIs(testingErrCode2, stack))
+	})
 }
 
 func TestBuildError(t *testing.T) {
@@ -100,7 +145,12 @@ func TestBuildError(t *testing.T) {
 		require.Equal(t, err, BuildError(err))
 	})
 	t.Run("errStack not Error type", func(t *testing.T) {
-		require.Equal(t, NewError(ErrCodeUnknown, "a"), BuildError(errors.New("a")))
+		err := errors.New("a")
+		require.Equal(t, &Error{code: ErrCodeUnknown, message: "a", cause: err}, BuildError(err))
+	})
+	t.Run("wrapped Error type", func(t *testing.T) {
+		err := fmt.Errorf("b: %w", NewError(ErrCodeNetDoesntKnowAboutPlace, "a"))
+		require.Equal(t, &Error{code: ErrCodeNetDoesntKnowAboutPlace, message: "b: a", cause: err}, BuildError(err))
 	})
 }
 
@@ -116,6 +166,24 @@ func TestError_Serialization(t *testing.T) {
 	require.Equal(t, errModel, &errNewModel)
 }
 
+func TestError_Serialization_WithCause(t *testing.T) {
+	errModel := Wrap(testingErrCode1, Wrap(testingErrCode2, errors.New("c"), "b"), "a")
+	bytes, err := json.Marshal(errModel)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`{"code":"testing.errCode1","message":"a: b: c","cause":`+
+			`{"code":"testing.errCode2","message":"b: c","cause":{"code":"","message":"c"}}}`,
+		string(bytes),
+	)
+
+	var errNewModel Error
+
+	require.NoError(t, json.Unmarshal(bytes, &errNewModel))
+	require.Equal(t, errModel, &errNewModel)
+	require.True(t, ErrorIs(testingErrCode2, &errNewModel))
+}
+
 func TestError_UnserializeFromBadData_UNmarshalErr(t *testing.T) {
 	var errNewModel Error
 
@@ -189,8 +257,78 @@ func TestErrStack_Serialization(t *testing.T) {
 	assert.Equal(t, stack, &newErrStack)
 }
 
+func TestErrStack_Unwrap(t *testing.T) {
+	stack := NewErrStack()
+	stack.Add(NewError(testingErrCode1, "a"))
+	stack.Add(NewError(testingErrCode2, "b"))
+
+	assert.Equal(t, []error{NewError(testingErrCode1, "a"), NewError(testingErrCode2, "b")}, stack.Unwrap())
+
+	var stateErr *Error
+
+	require.True(t, errors.As(stack, &stateErr))
+	assert.Equal(t, testingErrCode1, stateErr.GetCode())
+}
+
 func TestErrStack_UnserializeBadData_ExpectedErr(t *testing.T) {
 	var newErrStack ErrStack
 
 	assert.IsType(t, &json.UnmarshalTypeError{}, json.Unmarshal([]byte("[]"), &newErrStack))
 }
+
+func TestErrStack_Resolve(t *testing.T) {
+	stack := NewErrStack()
+	stack.Add(NewError(testingErrCode1, "a"))
+	stack.Add(NewError(testingErrCode2, "b"))
+
+	require.NoError(t, stack.Resolve(0, "fixed"))
+	assert.True(t, stack.HasErrs())
+	assert.True(t, stack.HasUnresolvedErrs())
+	assert.True(t, stack.GetErrs()[0].IsResolved())
+	assert.Equal(t, "fixed", stack.GetErrs()[0].GetResolution())
+
+	assert.Equal(
+		t,
+		&Error{code: ErrCodeStateErrorIsAlreadyResolved, message: "Error with index 0 is already resolved"},
+		stack.Resolve(0, "fixed"),
+	)
+	assert.Equal(
+		t,
+		&Error{code: ErrCodeStateErrorIsNotFound, message: "Error with index 2 is not found in stack"},
+		stack.Resolve(2, "fixed"),
+	)
+
+	require.NoError(t, stack.Resolve(1, "ignored"))
+	assert.False(t, stack.HasUnresolvedErrs())
+}
+
+func TestErrStack_ResolveAll_ResolvedErrsAreNotChanged(t *testing.T) {
+	stack := NewErrStack()
+	stack.Add(NewError(testingErrCode1, "a"))
+	stack.Add(NewError(testingErrCode2, "b"))
+	require.NoError(t, stack.Resolve(0, "fixed"))
+
+	stack.ResolveAll("retried")
+	assert.False(t, stack.HasUnresolvedErrs())
+	assert.Equal(t, "fixed", stack.GetErrs()[0].GetResolution())
+	assert.Equal(t, "retried", stack.GetErrs()[1].GetResolution())
+}
+
+func TestError_Serialization_Resolved(t *testing.T) {
+	stack := NewErrStack()
+	stack.Add(NewError(testingErrCode1, "a"))
+	require.NoError(t, stack.Resolve(0, "fixed"))
+
+	bytes, err := json.Marshal(stack)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`{"stack":[{"code":"testing.errCode1","message":"a","resolved":true,"resolution":"fixed"}]}`,
+		string(bytes),
+	)
+
+	var newStack ErrStack
+
+	require.NoError(t, json.Unmarshal(bytes, &newStack))
+	assert.Equal(t, stack, &newStack)
+}
@@ -0,0 +1,158 @@
+package state
+
+import (
+	"context"
+	"time"
+
+	"github.com/andrskom/gowfnet/clock"
+)
+
+// HistoryEntry is a record of a transition in the state.
+// Cancelled contains places which tokens were removed from by the cancellation region or completion of the transition.
+// Subprocess contains the state of the subprocess which was run by the transition, it is used for compensation.
+// Compensated is true if side effects of the transition are compensated, but the marking isn't moved back yet.
+type HistoryEntry struct {
+	TransitionID string    `json:"transitionId"`
+	From         []string  `json:"from"`
+	To           []string  `json:"to"`
+	Cancelled    []string  `json:"cancelled,omitempty"`
+	Subprocess   *State    `json:"subprocess,omitempty"`
+	Compensated  bool      `json:"compensated,omitempty"`
+	At           time.Time `json:"at"`
+}
+
+// RevertListenerInterface is an optional extension of ListenerInterface.
+// It is called when the last transition is reverted.
+type RevertListenerInterface interface {
+	OnRevert(ctx context.Context, st OpInterface, entry HistoryEntry)
+}
+
+// WithClock set clock which is used for time of history entries.
+func (s *State) WithClock(c clock.Clock) {
+	s.clock = c
+}
+
+// GetHistory returns copy of history of transitions, the last transition is the last entry.
+// Only moves with the transition id in ctx are recorded, see WithTransitionID.
+func (s *State) GetHistory() []HistoryEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]HistoryEntry, len(s.history))
+	copy(res, s.history)
+
+	return res
+}
+
+// MarkLastCompensated marks the last entry of the history as compensated.
+func (s *State) MarkLastCompensated() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.history) == 0 {
+		return NewError(ErrCodeStateHasNotHistory, "Can't mark as compensated, state has not history")
+	}
+
+	s.history[len(s.history)-1].Compensated = true
+
+	return nil
+}
+
+// RevertLast moves tokens back from places of the last transition to its input places,
+// restores cancelled tokens and removes the entry from the history.
+//
+// It works for error state too, because it is used for compensation after failures.
+// If the state is finished, it becomes not finished.
+func (s *State) RevertLast(ctx context.Context) (HistoryEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.history) == 0 {
+		return HistoryEntry{}, NewError(ErrCodeStateHasNotHistory, "Can't revert, state has not history")
+	}
+
+	entry := s.history[len(s.history)-1]
+
+	for _, place := range entry.To {
+		if _, ok := s.places[place]; !ok {
+			return HistoryEntry{}, NewErrorf(
+				ErrCodeStateHasNotTokenInPlace,
+				"Can't revert transition '%s', state has not token in place '%s'",
+				entry.TransitionID, place,
+			)
+		}
+	}
+
+	for _, place := range entry.To {
+		delete(s.places, place)
+	}
+
+	restored := make([]string, 0, len(entry.From)+len(entry.Cancelled))
+	restored = append(restored, entry.From...)
+	restored = append(restored, entry.Cancelled...)
+
+	for _, place := range restored {
+		if _, ok := s.places[place]; ok {
+			for _, toPlace := range entry.To {
+				s.places[toPlace] = struct{}{}
+			}
+
+			return HistoryEntry{}, NewErrorf(
+				ErrCodeStateAlreadyHasTokenInPlace,
+				"Can't revert transition '%s', state already has token in place '%s'",
+				entry.TransitionID, place,
+			)
+		}
+	}
+
+	for _, place := range restored {
+		s.places[place] = struct{}{}
+	}
+
+	s.history = s.history[:len(s.history)-1]
+	s.isFinished = false
+	s.outcome = ""
+
+	if listener, ok := s.listener.(RevertListenerInterface); ok {
+		listener.OnRevert(ctx, s, entry)
+	}
+
+	return entry, nil
+}
+
+func (s *State) recordMove(ctx context.Context, from []string, to []string) {
+	transitionID, ok := GetTransitionID(ctx)
+	if !ok {
+		return
+	}
+
+	s.history = append(s.history, HistoryEntry{
+		TransitionID: transitionID,
+		From:         append([]string{}, from...),
+		To:           append([]string{}, to...),
+		Subprocess:   s.children[transitionID],
+		At:           s.getClock().Now(),
+	})
+}
+
+func (s *State) recordCancel(ctx context.Context, cancelled []string) {
+	transitionID, ok := GetTransitionID(ctx)
+	if !ok || len(s.history) == 0 {
+		return
+	}
+
+	last := &s.history[len(s.history)-1]
+	if last.TransitionID != transitionID {
+		return
+	}
+
+	last.Cancelled = append(last.Cancelled, cancelled...)
+}
+
+func (s *State) getClock() clock.Clock {
+	if s.clock == nil {
+		s.clock = clock.NewSystem()
+	}
+
+	return s.clock
+}
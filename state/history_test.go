@@ -0,0 +1,184 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet/clock"
+)
+
+type testingRevertListener struct {
+	*StubListener
+	reverted []HistoryEntry
+}
+
+func (l *testingRevertListener) OnRevert(ctx context.Context, st OpInterface, entry HistoryEntry) {
+	l.reverted = append(l.reverted, entry)
+}
+
+func newStateWithHistory(t *testing.T) *State {
+	st := NewState()
+	st.WithClock(clock.NewFake(time.Date(2020, 10, 4, 0, 0, 0, 0, time.UTC)))
+
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"a"}))
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(WithTransitionID(context.Background(), "t1"), []string{"a"}, []string{"b", "c"}))
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(WithTransitionID(context.Background(), "t2"), []string{"b"}, []string{"d"}))
+
+	cancelled, err := st.CancelTokens(WithTransitionID(context.Background(), "t2"), []string{"c"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"c"}, cancelled)
+
+	return st
+}
+
+func TestState_GetHistory_OnlyTransitionsAreRecorded(t *testing.T) {
+	st := newStateWithHistory(t)
+	at := time.Date(2020, 10, 4, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(
+		t,
+		[]HistoryEntry{
+			{TransitionID: "t1", From: []string{"a"}, To: []string{"b", "c"}, At: at},
+			{TransitionID: "t2", From: []string{"b"}, To: []string{"d"}, Cancelled: []string{"c"}, At: at},
+		},
+		st.GetHistory(),
+	)
+}
+
+func TestState_RevertLast_ExpectedStateAndListenerCall(t *testing.T) {
+	st := newStateWithHistory(t)
+	listener := &testingRevertListener{StubListener: NewStubListener()}
+	st.WithListener(listener)
+	require.NoError(t, st.SetFinishedWithOutcome("a"))
+
+	entry, err := st.RevertLast(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "t2", entry.TransitionID)
+	assert.ElementsMatch(t, []string{"b", "c"}, st.GetPlaces())
+	assert.Len(t, st.GetHistory(), 1)
+	assert.False(t, st.IsFinished())
+	assert.Equal(t, "", st.GetOutcome())
+	assert.Equal(t, []HistoryEntry{entry}, listener.reverted)
+
+	_, err = st.RevertLast(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, st.GetPlaces())
+}
+
+func TestState_RevertLast_WithoutHistory_ExpectedErr(t *testing.T) {
+	st := NewState()
+
+	_, err := st.RevertLast(context.Background())
+	assert.Equal(t, &Error{code: ErrCodeStateHasNotHistory, message: "Can't revert, state has not history"}, err)
+}
+
+func TestState_RevertLast_HasNotTokenInOutput_ExpectedErr(t *testing.T) {
+	st := newStateWithHistory(t)
+	_, err := st.CancelTokens(context.Background(), []string{"d"})
+	require.NoError(t, err)
+
+	_, err = st.RevertLast(context.Background())
+	assert.Equal(
+		t,
+		&Error{code: ErrCodeStateHasNotTokenInPlace, message: "Can't revert transition 't2', state has not token in place 'd'"},
+		err,
+	)
+	assert.Len(t, st.GetHistory(), 2)
+}
+
+func TestState_RevertLast_AlreadyHasTokenInInput_ExpectedErr(t *testing.T) {
+	st := newStateWithHistory(t)
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"b"}))
+
+	_, err := st.RevertLast(context.Background())
+	assert.Equal(
+		t,
+		&Error{code: ErrCodeStateAlreadyHasTokenInPlace, message: "Can't revert transition 't2', state already has token in place 'b'"},
+		err,
+	)
+	assert.ElementsMatch(t, []string{"b", "d"}, st.GetPlaces())
+	assert.Len(t, st.GetHistory(), 2)
+}
+
+func TestState_RevertLast_ErrState_Reverted(t *testing.T) {
+	st := newStateWithHistory(t)
+	st.AddError(NewError(testingErrCode1, "a"))
+
+	_, err := st.RevertLast(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestState_MarkLastCompensated_LastEntryIsMarked(t *testing.T) {
+	st := newStateWithHistory(t)
+
+	require.NoError(t, st.MarkLastCompensated())
+
+	history := st.GetHistory()
+	assert.False(t, history[0].Compensated)
+	assert.True(t, history[1].Compensated)
+}
+
+func TestState_MarkLastCompensated_WithoutHistory_ExpectedErr(t *testing.T) {
+	assert.Equal(
+		t,
+		&Error{code: ErrCodeStateHasNotHistory, message: "Can't mark as compensated, state has not history"},
+		NewState().MarkLastCompensated(),
+	)
+}
+
+func TestState_Serialization_WithHistory(t *testing.T) {
+	st := NewState()
+	st.WithClock(clock.NewFake(time.Date(2020, 10, 4, 0, 0, 0, 0, time.UTC)))
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(WithTransitionID(context.Background(), "t"), []string{}, []string{"a"}))
+
+	bytes, err := json.Marshal(st)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`{"places":["a"],"errStack":{"stack":[]},"isFinished":false,`+
+			`"history":[{"transitionId":"t","from":[],"to":["a"],"at":"2020-10-04T00:00:00Z"}]}`,
+		string(bytes),
+	)
+
+	var newState State
+
+	require.NoError(t, json.Unmarshal(bytes, &newState))
+	assert.Equal(t, st.GetHistory(), newState.GetHistory())
+}
+
+func TestState_MoveTokensFromPlacesToPlaces_RunningChild_ChildIsKeptInHistory(t *testing.T) {
+	st := NewState()
+	st.WithClock(clock.NewFake(time.Date(2020, 10, 4, 0, 0, 0, 0, time.UTC)))
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"a"}))
+
+	child, err := st.NewChild("t")
+	require.NoError(t, err)
+	require.NoError(t, child.MoveTokensFromPlacesToPlaces(WithTransitionID(context.Background(), "c"), []string{}, []string{"x"}))
+
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(WithTransitionID(context.Background(), "t"), []string{"a"}, []string{"b"}))
+	st.RemoveChild("t")
+
+	assert.Same(t, child, st.GetHistory()[0].Subprocess)
+
+	bytes, err := json.Marshal(st)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`{"places":["b"],"errStack":{"stack":[]},"isFinished":false,`+
+			`"history":[{"transitionId":"t","from":["a"],"to":["b"],"subprocess":{"places":["x"],"errStack":{"stack":[]},`+
+			`"isFinished":false,"history":[{"transitionId":"c","from":[],"to":["x"],"at":"2020-10-04T00:00:00Z"}]},`+
+			`"at":"2020-10-04T00:00:00Z"}]}`,
+		string(bytes),
+	)
+
+	var newState State
+
+	require.NoError(t, json.Unmarshal(bytes, &newState))
+	require.NotNil(t, newState.GetHistory()[0].Subprocess)
+	assert.Equal(t, child.GetHistory(), newState.GetHistory()[0].Subprocess.GetHistory())
+}
@@ -28,3 +28,7 @@ func (l *StubListener) BeforeMove(ctx context.Context, st OpInterface, from []st
 }
 
 func (l *StubListener) AfterMove(ctx context.Context, st OpInterface, from []string, to []string) {}
+
+func (l *StubListener) OnCancel(ctx context.Context, st OpInterface, places []string) {}
+
+func (l *StubListener) OnRevert(ctx context.Context, st OpInterface, entry HistoryEntry) {}
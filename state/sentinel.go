@@ -0,0 +1,41 @@
+package state
+
+// Sentinels of error codes for errors.Is, e.g. errors.Is(err, state.ErrStateIsFinished).
+// Any *Error with the same code matches the sentinel.
+//
+// nolint:gochecknoglobals
+var (
+	ErrUnknown                           = NewSentinel(ErrCodeUnknown)
+	ErrStateHasNotTokenInPlace           = NewSentinel(ErrCodeStateHasNotTokenInPlace)
+	ErrStateAlreadyHasTokenInPlace       = NewSentinel(ErrCodeStateAlreadyHasTokenInPlace)
+	ErrStateAlreadyStarted               = NewSentinel(ErrCodeStateAlreadyStarted)
+	ErrStateIsNotStarted                 = NewSentinel(ErrCodeStateIsNotStarted)
+	ErrStateIsFinished                   = NewSentinel(ErrCodeStateIsFinished)
+	ErrStateIsAlreadyFinished            = NewSentinel(ErrCodeStateIsAlreadyFinished)
+	ErrStateIsErrorState                 = NewSentinel(ErrCodeStateIsErrorState)
+	ErrStateSubprocessAlreadyStarted     = NewSentinel(ErrCodeStateSubprocessAlreadyStarted)
+	ErrStateSubprocessIsNotStarted       = NewSentinel(ErrCodeStateSubprocessIsNotStarted)
+	ErrStateErrorIsNotFound              = NewSentinel(ErrCodeStateErrorIsNotFound)
+	ErrStateErrorIsAlreadyResolved       = NewSentinel(ErrCodeStateErrorIsAlreadyResolved)
+	ErrStateHasNotHistory                = NewSentinel(ErrCodeStateHasNotHistory)
+	ErrStateHasTokensAfterCompletion     = NewSentinel(ErrCodeStateHasTokensAfterCompletion)
+	ErrNetDoesntKnowAboutTransition      = NewSentinel(ErrCodeNetDoesntKnowAboutTransition)
+	ErrNetDoesntKnowAboutPlace           = NewSentinel(ErrCodeNetDoesntKnowAboutPlace)
+	ErrRegistryNetAlreadyRegistered      = NewSentinel(ErrCodeRegistryNetAlreadyRegistered)
+	ErrRegistryNetNotRegistered          = NewSentinel(ErrCodeRegistryNetNotRegistered)
+	ErrNetHasNotRegistry                 = NewSentinel(ErrCodeNetHasNotRegistry)
+	ErrNetStateDoesntSupportNesting      = NewSentinel(ErrCodeNetStateDoesntSupportNesting)
+	ErrNetStateDoesntSupportHistory      = NewSentinel(ErrCodeNetStateDoesntSupportHistory)
+	ErrNetStateDoesntSupportResolving    = NewSentinel(ErrCodeNetStateDoesntSupportResolving)
+	ErrNetTransitionIsRoutedToErrorPlace = NewSentinel(ErrCodeNetTransitionIsRoutedToErrorPlace)
+	ErrNetTransitionIsNotReversible      = NewSentinel(ErrCodeNetTransitionIsNotReversible)
+	ErrNetStateDoesntSupportCancellation = NewSentinel(ErrCodeNetStateDoesntSupportCancellation)
+	ErrStatePayloadPlaceIsNotOutput      = NewSentinel(ErrCodeStatePayloadPlaceIsNotOutput)
+	ErrNetPlaceIsReservedBySubprocess    = NewSentinel(ErrCodeNetPlaceIsReservedBySubprocess)
+)
+
+// NewSentinel init err which matches any *Error with the code by errors.Is.
+// Use it for sentinels of custom codes.
+func NewSentinel(errorCode ErrCode) *Error {
+	return NewError(errorCode, string(errorCode))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"sync"
+
+	"github.com/andrskom/gowfnet/clock"
 )
 
 type ErrStackInterface interface {
@@ -19,12 +21,22 @@ type ListenerInterface interface {
 	AfterMove(ctx context.Context, st OpInterface, from []string, to []string)
 }
 
+// CancelListenerInterface is an optional extension of ListenerInterface.
+// It is called when tokens are removed from places of a cancellation region.
+type CancelListenerInterface interface {
+	OnCancel(ctx context.Context, st OpInterface, places []string)
+}
+
 // State of net.
 // If you need custom serialization you can use this struct embedded in your implementation.
 type State struct {
 	places     map[string]struct{}
 	errStack   *ErrStack
 	isFinished bool
+	outcome    string
+	children   map[string]*State
+	history    []HistoryEntry
+	clock      clock.Clock
 	listener   ListenerInterface
 	mu         sync.Mutex
 }
@@ -36,6 +48,8 @@ func NewState() *State {
 		errStack:   NewErrStack(),     // We can init inside value object without DI.
 		listener:   NewStubListener(), // We can init inside value object without DI. And set it after if need.
 		isFinished: false,
+		children:   make(map[string]*State),
+		clock:      clock.NewSystem(),
 	}
 }
 
@@ -60,8 +74,26 @@ func (s *State) GetPlaces() []string {
 }
 
 // IsError return true if that is errStack state.
+// Resolved errors are not taken into account.
 func (s *State) IsError() bool {
-	return s.errStack.HasErrs()
+	return s.errStack.HasUnresolvedErrs()
+}
+
+// ResolveError by index in the err stack with the reason of resolution.
+// The state is not error state after resolving of all errors.
+func (s *State) ResolveError(index int, resolution string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.errStack.Resolve(index, resolution)
+}
+
+// ResolveErrors resolves all unresolved errors with the reason of resolution.
+func (s *State) ResolveErrors(resolution string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errStack.ResolveAll(resolution)
 }
 
 // AddError state.
@@ -82,17 +114,30 @@ func (s *State) IsFinished() bool {
 
 // IsFinished the net.
 func (s *State) SetFinished() error {
+	return s.SetFinishedWithOutcome("")
+}
+
+// SetFinishedWithOutcome finishes the state in a terminal place of the outcome.
+// The outcome is set before the listener is called.
+func (s *State) SetFinishedWithOutcome(outcome string) error {
 	if s.IsFinished() {
 		return NewError(ErrCodeStateIsAlreadyFinished, "Can't set finished state, because state is already finished")
 	}
 
 	s.isFinished = true
+	s.outcome = outcome
 
 	s.listener.OnFinish(s)
 
 	return nil
 }
 
+// GetOutcome returns the name of the outcome of the finished state.
+// Returns empty string if the state isn't finished or is finished without outcome.
+func (s *State) GetOutcome() string {
+	return s.outcome
+}
+
 // IsStarted the net.
 func (s *State) IsStarted() bool {
 	return len(s.places) > 0
@@ -143,40 +188,133 @@ func (s *State) MoveTokensFromPlacesToPlaces(ctx context.Context, from []string,
 		s.places[place] = struct{}{}
 	}
 
+	s.recordMove(ctx, from, to)
+
 	s.listener.AfterMove(ctx, s, from, to)
 
 	return nil
 }
 
+// CancelTokens removes tokens from places, places without tokens are skipped.
+// Returns places which tokens were removed from.
+func (s *State) CancelTokens(ctx context.Context, places []string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.IsError() {
+		return nil, NewError(ErrCodeStateIsErrorState, "Can't cancel tokens, state is errStack")
+	}
+
+	if s.IsFinished() {
+		return nil, NewError(ErrCodeStateIsFinished, "Can't cancel tokens, state is finished")
+	}
+
+	cancelled := make([]string, 0, len(places))
+
+	for _, place := range places {
+		if _, ok := s.places[place]; !ok {
+			continue
+		}
+
+		delete(s.places, place)
+		cancelled = append(cancelled, place)
+	}
+
+	if len(cancelled) == 0 {
+		return cancelled, nil
+	}
+
+	s.recordCancel(ctx, cancelled)
+
+	if listener, ok := s.listener.(CancelListenerInterface); ok {
+		listener.OnCancel(ctx, s, cancelled)
+	}
+
+	return cancelled, nil
+}
+
+// NewChild init state of the subprocess which is run by the transition.
+// The child uses the clock of the state.
+func (s *State) NewChild(transitionID string) (*State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.children[transitionID]; ok {
+		return nil, NewErrorf(
+			ErrCodeStateSubprocessAlreadyStarted,
+			"Subprocess of transition '%s' is already started",
+			transitionID,
+		)
+	}
+
+	child := NewState()
+	child.clock = s.getClock()
+	s.children[transitionID] = child
+
+	return child, nil
+}
+
+// GetChild returns state of the subprocess which is run by the transition.
+func (s *State) GetChild(transitionID string) (*State, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	child, ok := s.children[transitionID]
+
+	return child, ok
+}
+
+// RemoveChild state of the subprocess which is run by the transition.
+func (s *State) RemoveChild(transitionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.children, transitionID)
+}
+
 type jsonState struct {
-	Places     []string  `json:"places"`
-	ErrStack   *ErrStack `json:"errStack"`
-	IsFinished bool      `json:"isFinished"`
+	Places     []string          `json:"places"`
+	ErrStack   *ErrStack         `json:"errStack"`
+	IsFinished bool              `json:"isFinished"`
+	Outcome    string            `json:"outcome,omitempty"`
+	Children   map[string]*State `json:"children,omitempty"`
+	History    []HistoryEntry    `json:"history,omitempty"`
 }
 
 func (s *State) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.toJSON())
+}
+
+func (s *State) UnmarshalJSON(data []byte) error {
+	var jsonSt jsonState
+
+	if err := json.Unmarshal(data, &jsonSt); err != nil {
+		return err
+	}
+
+	s.fromJSON(jsonSt)
+
+	return nil
+}
+
+func (s *State) toJSON() jsonState {
 	jsonPlaces := make([]string, 0, len(s.places))
 
 	for place := range s.places {
 		jsonPlaces = append(jsonPlaces, place)
 	}
 
-	jsonSt := jsonState{
+	return jsonState{
 		Places:     jsonPlaces,
 		ErrStack:   s.errStack,
 		IsFinished: s.isFinished,
+		Outcome:    s.outcome,
+		Children:   s.children,
+		History:    s.history,
 	}
-
-	return json.Marshal(jsonSt)
 }
 
-func (s *State) UnmarshalJSON(data []byte) error {
-	var jsonSt jsonState
-
-	if err := json.Unmarshal(data, &jsonSt); err != nil {
-		return err
-	}
-
+func (s *State) fromJSON(jsonSt jsonState) {
 	s.places = make(map[string]struct{})
 
 	for _, place := range jsonSt.Places {
@@ -185,10 +323,20 @@ func (s *State) UnmarshalJSON(data []byte) error {
 
 	s.errStack = jsonSt.ErrStack
 	s.isFinished = jsonSt.IsFinished
+	s.outcome = jsonSt.Outcome
+
+	s.children = jsonSt.Children
+	if s.children == nil {
+		s.children = make(map[string]*State)
+	}
+
+	s.history = jsonSt.History
 
 	if s.listener == nil {
 		s.listener = NewStubListener()
 	}
 
-	return nil
+	if s.clock == nil {
+		s.clock = clock.NewSystem()
+	}
 }
@@ -27,9 +27,10 @@ func TestState_GetPlaces(t *testing.T) {
 
 func TestState_AddError(t *testing.T) {
 	state := NewState()
+	eErr := errors.New("a")
 
 	{
-		state.AddError(errors.New("a"))
+		state.AddError(eErr)
 		errStack := state.GetErrorStack()
 
 		assert.Len(t, errStack.GetErrs(), 1)
@@ -37,6 +38,7 @@ func TestState_AddError(t *testing.T) {
 			Error{
 				code:    ErrCodeUnknown,
 				message: "a",
+				cause:   eErr,
 			},
 			errStack.GetErrs()[0],
 		)
@@ -51,6 +53,7 @@ func TestState_AddError(t *testing.T) {
 			Error{
 				code:    ErrCodeUnknown,
 				message: "a",
+				cause:   eErr,
 			},
 			errStack.GetErrs()[0],
 		)
@@ -154,6 +157,16 @@ func TestState_MoveTokensFromPlacesToPlaces_CorrectStateForOperation_ExpectedSta
 	assert.Contains(t, state.GetPlaces(), "d")
 }
 
+func TestState_AddError_PlainErr_CauseIsKept(t *testing.T) {
+	st := NewState()
+	eErr := errors.New("a")
+
+	st.AddError(eErr)
+
+	assert.True(t, errors.Is(st.GetErrorStack(), eErr))
+	assert.Same(t, eErr, errors.Unwrap(&st.GetErrorStack().GetErrs()[0]))
+}
+
 func TestState_Serialization(t *testing.T) {
 	state := NewState()
 	require.NoError(t, state.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"a"}))
@@ -164,8 +177,8 @@ func TestState_Serialization(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(
 		t,
-		"{\"places\":[\"a\"],\"errStack\":{\"stack\":[{\"code\":\"gowfnet.unknown\",\"message\":\"b\"}]},"+
-			"\"isFinished\":true}",
+		"{\"places\":[\"a\"],\"errStack\":{\"stack\":[{\"code\":\"gowfnet.unknown\",\"message\":\"b\","+
+			"\"cause\":{\"code\":\"\",\"message\":\"b\"}}]},\"isFinished\":true}",
 		string(bytes),
 	)
 
@@ -191,3 +204,176 @@ func TestState_WithListener(t *testing.T) {
 
 	assert.Same(t, listener, st.listener)
 }
+
+func TestState_NewChild(t *testing.T) {
+	st := NewState()
+
+	child, err := st.NewChild("t")
+	require.NoError(t, err)
+	assert.Equal(t, NewState(), child)
+
+	actual, ok := st.GetChild("t")
+	assert.True(t, ok)
+	assert.Same(t, child, actual)
+}
+
+func TestState_NewChild_AlreadyStarted_ExpectedErr(t *testing.T) {
+	st := NewState()
+
+	_, err := st.NewChild("t")
+	require.NoError(t, err)
+
+	_, err = st.NewChild("t")
+	assert.Equal(
+		t,
+		&Error{code: ErrCodeStateSubprocessAlreadyStarted, message: "Subprocess of transition 't' is already started"},
+		err,
+	)
+}
+
+func TestState_RemoveChild(t *testing.T) {
+	st := NewState()
+
+	_, err := st.NewChild("t")
+	require.NoError(t, err)
+
+	st.RemoveChild("t")
+
+	_, ok := st.GetChild("t")
+	assert.False(t, ok)
+}
+
+func TestState_Serialization_WithChildren(t *testing.T) {
+	st := NewState()
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"a"}))
+
+	child, err := st.NewChild("t")
+	require.NoError(t, err)
+	require.NoError(t, child.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"b"}))
+
+	bytes, err := json.Marshal(st)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`{"places":["a"],"errStack":{"stack":[]},"isFinished":false,`+
+			`"children":{"t":{"places":["b"],"errStack":{"stack":[]},"isFinished":false}}}`,
+		string(bytes),
+	)
+
+	var newState State
+
+	require.NoError(t, json.Unmarshal(bytes, &newState))
+	assert.Equal(t, st, &newState)
+}
+
+type testingCancelListener struct {
+	*StubListener
+	cancelled []string
+}
+
+func (l *testingCancelListener) OnCancel(ctx context.Context, st OpInterface, places []string) {
+	l.cancelled = append(l.cancelled, places...)
+}
+
+func TestState_CancelTokens_ExpectedStateAndListenerCall(t *testing.T) {
+	st := NewState()
+	listener := &testingCancelListener{StubListener: NewStubListener()}
+	st.WithListener(listener)
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"a", "b"}))
+
+	cancelled, err := st.CancelTokens(context.Background(), []string{"a", "c"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, cancelled)
+	assert.Equal(t, []string{"a"}, listener.cancelled)
+	assert.Equal(t, []string{"b"}, st.GetPlaces())
+}
+
+func TestState_CancelTokens_NothingToCancel_ListenerIsNotCalled(t *testing.T) {
+	st := NewState()
+	listener := &testingCancelListener{StubListener: NewStubListener()}
+	st.WithListener(listener)
+
+	cancelled, err := st.CancelTokens(context.Background(), []string{"a"})
+	require.NoError(t, err)
+	assert.Empty(t, cancelled)
+	assert.Nil(t, listener.cancelled)
+}
+
+func TestState_CancelTokens_ErrState_ExpectedErr(t *testing.T) {
+	st := NewState()
+	st.AddError(errors.New("a"))
+
+	_, err := st.CancelTokens(context.Background(), []string{"a"})
+	assert.Equal(
+		t,
+		&Error{code: ErrCodeStateIsErrorState, message: "Can't cancel tokens, state is errStack"},
+		err,
+	)
+}
+
+func TestState_CancelTokens_StateIsFinished_ExpectedErr(t *testing.T) {
+	st := NewState()
+	require.NoError(t, st.SetFinished())
+
+	_, err := st.CancelTokens(context.Background(), []string{"a"})
+	assert.Equal(
+		t,
+		&Error{code: ErrCodeStateIsFinished, message: "Can't cancel tokens, state is finished"},
+		err,
+	)
+}
+
+func TestState_SetFinishedWithOutcome(t *testing.T) {
+	st := NewState()
+	assert.Equal(t, "", st.GetOutcome())
+
+	require.NoError(t, st.SetFinishedWithOutcome("approved"))
+	assert.True(t, st.IsFinished())
+	assert.Equal(t, "approved", st.GetOutcome())
+
+	err := st.SetFinishedWithOutcome("rejected")
+	assert.True(t, ErrorIs(ErrCodeStateIsAlreadyFinished, err))
+	assert.Equal(t, "approved", st.GetOutcome())
+}
+
+func TestState_Serialization_WithOutcome(t *testing.T) {
+	st := NewState()
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"a"}))
+	require.NoError(t, st.SetFinishedWithOutcome("approved"))
+
+	bytes, err := json.Marshal(st)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`{"places":["a"],"errStack":{"stack":[]},"isFinished":true,"outcome":"approved"}`,
+		string(bytes),
+	)
+
+	var newState State
+
+	require.NoError(t, json.Unmarshal(bytes, &newState))
+	assert.Equal(t, st, &newState)
+}
+
+func TestState_ResolveError_StateIsNotErrorState(t *testing.T) {
+	st := NewState()
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"a"}))
+	st.AddError(errors.New("a"))
+	require.True(t, st.IsError())
+
+	require.NoError(t, st.ResolveError(0, "fixed"))
+	assert.False(t, st.IsError())
+	assert.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{"a"}, []string{"b"}))
+
+	assert.True(t, ErrorIs(ErrCodeStateErrorIsNotFound, st.ResolveError(1, "fixed")))
+}
+
+func TestState_ResolveErrors(t *testing.T) {
+	st := NewState()
+	st.AddError(errors.New("a"))
+	st.AddError(errors.New("b"))
+
+	st.ResolveErrors("fixed")
+	assert.False(t, st.IsError())
+	assert.Len(t, st.GetErrorStack().GetErrs(), 2)
+}
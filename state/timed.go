@@ -0,0 +1,160 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/andrskom/gowfnet/clock"
+)
+
+// Timed is a state which records the moment when a token was put into each place.
+// Use it for nets with timed transitions, see scheduler pkg.
+type Timed struct {
+	*State
+	clock    clock.Clock
+	markedAt map[string]time.Time
+	mu       sync.Mutex
+}
+
+// NewTimed init new timed state.
+func NewTimed(c clock.Clock) *Timed {
+	st := NewState()
+	st.WithClock(c)
+
+	return &Timed{
+		State:    st,
+		clock:    c,
+		markedAt: make(map[string]time.Time),
+	}
+}
+
+// WithClock set clock to state.
+// Use it after unmarshalling if you need not system clock.
+func (t *Timed) WithClock(c clock.Clock) {
+	t.clock = c
+	t.State.WithClock(c)
+}
+
+// GetMarkedAt returns the moment when the token was put into the place.
+// Returns false if there is no token in the place.
+func (t *Timed) GetMarkedAt(place string) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	res, ok := t.markedAt[place]
+
+	return res, ok
+}
+
+// MoveTokensFromPlacesToPlaces for create new state and remember the moment of marking of new places.
+func (t *Timed) MoveTokensFromPlacesToPlaces(ctx context.Context, from []string, to []string) error {
+	if err := t.State.MoveTokensFromPlacesToPlaces(ctx, from, to); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.clock.Now()
+
+	for _, place := range from {
+		delete(t.markedAt, place)
+	}
+
+	for _, place := range to {
+		t.markedAt[place] = now
+	}
+
+	return nil
+}
+
+// CancelTokens removes tokens from places and forgets the moment of their marking.
+func (t *Timed) CancelTokens(ctx context.Context, places []string) ([]string, error) {
+	cancelled, err := t.State.CancelTokens(ctx, places)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, place := range cancelled {
+		delete(t.markedAt, place)
+	}
+
+	return cancelled, nil
+}
+
+// RevertLast reverts the last transition, restored places are marked at the current moment.
+func (t *Timed) RevertLast(ctx context.Context) (HistoryEntry, error) {
+	entry, err := t.State.RevertLast(ctx)
+	if err != nil {
+		return entry, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.clock.Now()
+
+	for _, place := range entry.To {
+		delete(t.markedAt, place)
+	}
+
+	for _, place := range entry.From {
+		t.markedAt[place] = now
+	}
+
+	for _, place := range entry.Cancelled {
+		t.markedAt[place] = now
+	}
+
+	return entry, nil
+}
+
+type jsonTimedState struct {
+	jsonState
+	MarkedAt map[string]time.Time `json:"markedAt"`
+}
+
+func (t *Timed) MarshalJSON() ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return json.Marshal(jsonTimedState{
+		jsonState: t.State.toJSON(),
+		MarkedAt:  t.markedAt,
+	})
+}
+
+func (t *Timed) UnmarshalJSON(data []byte) error {
+	var jsonSt jsonTimedState
+
+	if err := json.Unmarshal(data, &jsonSt); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.State == nil {
+		t.State = NewState()
+	}
+
+	t.State.fromJSON(jsonSt.jsonState)
+
+	t.markedAt = jsonSt.MarkedAt
+	if t.markedAt == nil {
+		t.markedAt = make(map[string]time.Time)
+	}
+
+	if t.clock == nil {
+		t.clock = clock.NewSystem()
+	}
+
+	t.State.WithClock(t.clock)
+
+	return nil
+}
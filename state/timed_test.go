@@ -0,0 +1,122 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet/clock"
+)
+
+func TestNewTimed(t *testing.T) {
+	st := NewTimed(clock.NewSystem())
+
+	assert.False(t, st.IsStarted())
+	_, ok := st.GetMarkedAt("a")
+	assert.False(t, ok)
+}
+
+func TestTimed_MoveTokensFromPlacesToPlaces_ExpectedMarkedAt(t *testing.T) {
+	now := time.Date(2020, 10, 4, 0, 0, 0, 0, time.UTC)
+	c := clock.NewFake(now)
+	st := NewTimed(c)
+
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"a", "b"}))
+	c.Add(time.Hour)
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{"a"}, []string{"c"}))
+
+	_, ok := st.GetMarkedAt("a")
+	assert.False(t, ok)
+
+	markedAt, ok := st.GetMarkedAt("b")
+	assert.True(t, ok)
+	assert.Equal(t, now, markedAt)
+
+	markedAt, ok = st.GetMarkedAt("c")
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(time.Hour), markedAt)
+}
+
+func TestTimed_MoveTokensFromPlacesToPlaces_MoveErr_MarkedAtIsNotChanged(t *testing.T) {
+	st := NewTimed(clock.NewFake(time.Date(2020, 10, 4, 0, 0, 0, 0, time.UTC)))
+
+	err := st.MoveTokensFromPlacesToPlaces(context.Background(), []string{"a"}, []string{"b"})
+	assert.True(t, ErrorIs(ErrCodeStateHasNotTokenInPlace, err))
+
+	_, ok := st.GetMarkedAt("b")
+	assert.False(t, ok)
+}
+
+func TestTimed_CancelTokens_MarkedAtIsForgotten(t *testing.T) {
+	st := NewTimed(clock.NewFake(time.Date(2020, 10, 4, 0, 0, 0, 0, time.UTC)))
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"a", "b"}))
+
+	cancelled, err := st.CancelTokens(context.Background(), []string{"a"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, cancelled)
+
+	_, ok := st.GetMarkedAt("a")
+	assert.False(t, ok)
+
+	_, ok = st.GetMarkedAt("b")
+	assert.True(t, ok)
+}
+
+func TestTimed_RevertLast_RestoredPlacesAreMarkedNow(t *testing.T) {
+	now := time.Date(2020, 10, 4, 0, 0, 0, 0, time.UTC)
+	c := clock.NewFake(now)
+	st := NewTimed(c)
+
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"a"}))
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(WithTransitionID(context.Background(), "t"), []string{"a"}, []string{"b"}))
+	c.Add(time.Hour)
+
+	_, err := st.RevertLast(context.Background())
+	require.NoError(t, err)
+
+	_, ok := st.GetMarkedAt("b")
+	assert.False(t, ok)
+
+	markedAt, ok := st.GetMarkedAt("a")
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(time.Hour), markedAt)
+}
+
+func TestTimed_Serialization(t *testing.T) {
+	st := NewTimed(clock.NewFake(time.Date(2020, 10, 4, 0, 0, 0, 0, time.UTC)))
+	require.NoError(t, st.MoveTokensFromPlacesToPlaces(context.Background(), []string{}, []string{"a"}))
+
+	bytes, err := json.Marshal(st)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		`{"places":["a"],"errStack":{"stack":[]},"isFinished":false,"markedAt":{"a":"2020-10-04T00:00:00Z"}}`,
+		string(bytes),
+	)
+
+	var newSt Timed
+
+	require.NoError(t, json.Unmarshal(bytes, &newSt))
+	assert.Equal(t, st.GetPlaces(), newSt.GetPlaces())
+	assert.Equal(t, st.markedAt, newSt.markedAt)
+	assert.Equal(t, clock.NewSystem(), newSt.clock)
+}
+
+func TestTimed_UnmarshalJSON_UnexpectedJSON_ExpectedErr(t *testing.T) {
+	var st Timed
+
+	assert.IsType(t, &json.UnmarshalTypeError{}, json.Unmarshal([]byte("[]"), &st))
+}
+
+func TestTimed_WithClock(t *testing.T) {
+	st := NewTimed(clock.NewSystem())
+
+	c := clock.NewFake(time.Now())
+	st.WithClock(c)
+
+	assert.Same(t, c, st.clock)
+}
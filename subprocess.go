@@ -0,0 +1,36 @@
+package gowfnet
+
+import (
+	"context"
+
+	"github.com/andrskom/gowfnet/state"
+)
+
+// SubprocessListenerInterface is an optional extension of ListenerInterface.
+// OnSubprocessRemoval is called when the running subprocess of the transition is removed without completion,
+// e.g. by a cancellation region or compensation. AfterTransition and OnTransitionFailure aren't called for it.
+type SubprocessListenerInterface interface {
+	OnSubprocessRemoval(ctx context.Context, transitionID string, state StateOpInterface)
+}
+
+// removeSubprocess removes the running subprocess and subprocesses running in it.
+func (n *Net) removeSubprocess(ctx context.Context, s NestedStateInterface, transitionID string) {
+	if child, ok := s.GetChild(transitionID); ok {
+		if subNet, err := n.getSubprocessNet(transitionID); err == nil {
+			for _, id := range subNet.getSubprocessTransitionIDs() {
+				if _, ok := child.GetChild(id); ok {
+					subNet.removeSubprocess(ctx, child, id)
+				}
+			}
+		}
+	}
+
+	s.RemoveChild(transitionID)
+	n.notifySubprocessRemoval(ctx, s, transitionID)
+}
+
+func (n *Net) notifySubprocessRemoval(ctx context.Context, s StateOpInterface, transitionID string) {
+	if listener, ok := n.listener.(SubprocessListenerInterface); ok {
+		listener.OnSubprocessRemoval(state.WithTransitionID(ctx, transitionID), transitionID, s)
+	}
+}
@@ -0,0 +1,96 @@
+package gowfnet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/state"
+)
+
+type testingRemovalListener struct {
+	*StubListener
+	removed []string
+	states  []StateOpInterface
+}
+
+func (l *testingRemovalListener) OnSubprocessRemoval(ctx context.Context, transitionID string, s StateOpInterface) {
+	ctxTransitionID, _ := state.GetTransitionID(ctx)
+	l.removed = append(l.removed, transitionID+":"+ctxTransitionID)
+	l.states = append(l.states, s)
+}
+
+func newNestedCancelNet(t *testing.T) *Net {
+	sign := cfg.Minimal{
+		Start:       "start",
+		Finish:      "finish",
+		Places:      []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{"approve": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}}},
+	}
+	review := cfg.Minimal{
+		Start:       "start",
+		Finish:      "finish",
+		Places:      []cfg.StringID{"start", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{"sign": {From: []cfg.StringID{"start"}, To: []cfg.StringID{"finish"}, Subprocess: "sign"}},
+	}
+
+	registry := cfg.NewRegistry()
+	require.NoError(t, registry.AddWithName("sign", sign))
+	require.NoError(t, registry.AddWithName("review", review))
+
+	net := NewNet(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "payment", "shipping", "cancelled", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"order":  {From: []cfg.StringID{"start"}, To: []cfg.StringID{"payment", "shipping"}},
+			"ship":   {From: []cfg.StringID{"shipping"}, To: []cfg.StringID{"finish"}, Subprocess: "review"},
+			"cancel": {From: []cfg.StringID{"payment"}, To: []cfg.StringID{"cancelled"}, Cancel: []cfg.StringID{"shipping"}},
+		},
+	})
+	net.WithRegistry(registry)
+
+	return net
+}
+
+func TestNet_Transit_CancelRegionWithRunningSubprocesses_RemovalIsNotified(t *testing.T) {
+	net := newNestedCancelNet(t)
+	listener := &testingRemovalListener{StubListener: NewStubListener()}
+	net.WithListener(listener)
+
+	ctx := context.Background()
+	st := state.NewState()
+	require.NoError(t, net.Start(ctx, st))
+	require.NoError(t, net.Transit(ctx, st, "order"))
+	require.NoError(t, net.Transit(ctx, st, "ship"))
+	require.NoError(t, net.TransitSubprocess(ctx, st, []string{"ship"}, "sign"))
+
+	child, ok := st.GetChild("ship")
+	require.True(t, ok)
+
+	require.NoError(t, net.Transit(ctx, st, "cancel"))
+	assert.Equal(t, []string{"sign:sign", "ship:ship"}, listener.removed)
+	assert.Equal(t, []StateOpInterface{child, st}, listener.states)
+
+	_, ok = child.GetChild("sign")
+	assert.False(t, ok)
+}
+
+func TestNet_Compensate_RunningSubprocess_RemovalIsNotified(t *testing.T) {
+	net, _ := newSubprocessSagaNet(t)
+	listener := &testingRemovalListener{StubListener: NewStubListener()}
+	net.WithListener(listener)
+
+	ctx := context.Background()
+	st := state.NewState()
+	require.NoError(t, net.Start(ctx, st))
+	require.NoError(t, net.Transit(ctx, st, "reserve"))
+	require.NoError(t, net.Transit(ctx, st, "pay"))
+
+	require.NoError(t, net.Compensate(ctx, st))
+	assert.Equal(t, []string{"pay:pay"}, listener.removed)
+	assert.Equal(t, []StateOpInterface{st}, listener.states)
+}
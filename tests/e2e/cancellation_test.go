@@ -0,0 +1,81 @@
+// nolint:gochecknoglobals
+package e2e
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet"
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/listener/channel"
+	"github.com/andrskom/gowfnet/state"
+)
+
+// Cancel region pattern, parallel branches are cancelled by the customer.
+var orderCfg = cfg.Minimal{
+	Start:  "start",
+	Finish: "finish",
+	Places: []cfg.StringID{"start", "toPay", "toPack", "paid", "packed", "finish"},
+	Transitions: cfg.MinimalTransitionRegistry{
+		"order": {
+			From: []cfg.StringID{"start"},
+			To:   []cfg.StringID{"toPay", "toPack"},
+		},
+		"pay": {
+			From: []cfg.StringID{"toPay"},
+			To:   []cfg.StringID{"paid"},
+		},
+		"pack": {
+			From: []cfg.StringID{"toPack"},
+			To:   []cfg.StringID{"packed"},
+		},
+		"ship": {
+			From: []cfg.StringID{"paid", "packed"},
+			To:   []cfg.StringID{"finish"},
+		},
+		"cancel": {
+			From:   []cfg.StringID{"toPay"},
+			To:     []cfg.StringID{"finish"},
+			Cancel: []cfg.StringID{"toPack", "packed"},
+		},
+	},
+}
+
+func TestCancellation_CancelRegion(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	listener := channel.New(100)
+	net := gowfnet.NewNet(orderCfg)
+	net.WithListener(listener)
+
+	st := state.NewState()
+
+	r.NoError(net.Start(ctx, st))
+	r.NoError(net.Transit(ctx, st, "order"))
+	r.NoError(net.Transit(ctx, st, "pack"))
+	r.NoError(net.Transit(ctx, st, "cancel"))
+	r.True(st.IsFinished())
+	r.Equal([]string{"finish"}, st.GetPlaces())
+
+	r.Equal("start", listener.ReadEvt())
+	r.Equal("move_FROM:[]_TO:[start]", listener.ReadEvt())
+	r.Equal("moved_FROM:[]_TO:[start]", listener.ReadEvt())
+	r.Equal("started", listener.ReadEvt())
+	r.Equal("transit_order", listener.ReadEvt())
+	r.Equal("move_FROM:[start]_TO:[toPay toPack]", listener.ReadEvt())
+	r.Equal("moved_FROM:[start]_TO:[toPay toPack]", listener.ReadEvt())
+	r.Equal("order_transited", listener.ReadEvt())
+	r.Equal("transit_pack", listener.ReadEvt())
+	r.Equal("move_FROM:[toPack]_TO:[packed]", listener.ReadEvt())
+	r.Equal("moved_FROM:[toPack]_TO:[packed]", listener.ReadEvt())
+	r.Equal("pack_transited", listener.ReadEvt())
+	r.Equal("transit_cancel", listener.ReadEvt())
+	r.Equal("move_FROM:[toPay]_TO:[finish]", listener.ReadEvt())
+	r.Equal("moved_FROM:[toPay]_TO:[finish]", listener.ReadEvt())
+	r.Equal("cancelled_[packed]", listener.ReadEvt())
+	r.Equal("finished", listener.ReadEvt())
+	r.Equal("cancel_transited", listener.ReadEvt())
+}
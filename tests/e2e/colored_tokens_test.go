@@ -0,0 +1,63 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet"
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/state"
+)
+
+type review struct {
+	Document string `json:"document"`
+	Reviewer string `json:"reviewer"`
+}
+
+func TestColoredTokens_PayloadsAreMappedByTransitions(t *testing.T) {
+	r := require.New(t)
+
+	config := cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "review", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"assign": {
+				From: []cfg.StringID{"start"},
+				To:   []cfg.StringID{"review"},
+			},
+			"approve": {
+				From: []cfg.StringID{"review"},
+				To:   []cfg.StringID{"finish"},
+			},
+		},
+	}
+
+	mappers := state.NewPayloadMappers[review]()
+	mappers.Add("assign", func(ctx context.Context, in map[string]review) (map[string]review, error) {
+		doc := in["start"]
+		doc.Reviewer = "bob"
+
+		return map[string]review{"review": doc}, nil
+	})
+
+	net := gowfnet.NewNet(config)
+	st := state.NewColored[review](mappers, state.NewJSONPayloadCodec[review]())
+
+	r.NoError(net.Start(context.Background(), st))
+	r.NoError(st.SetPayload("start", review{Document: "contract"}))
+	r.NoError(net.Transit(context.Background(), st, "assign"))
+
+	payload, ok := st.GetPayload("review")
+	r.True(ok)
+	r.Equal(review{Document: "contract", Reviewer: "bob"}, payload)
+
+	r.NoError(net.Transit(context.Background(), st, "approve"))
+	r.True(st.IsFinished())
+
+	payload, ok = st.GetPayload("finish")
+	r.True(ok)
+	r.Equal(review{Document: "contract", Reviewer: "bob"}, payload)
+}
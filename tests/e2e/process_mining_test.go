@@ -0,0 +1,60 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet"
+	"github.com/andrskom/gowfnet/clock"
+	"github.com/andrskom/gowfnet/conformance"
+	"github.com/andrskom/gowfnet/discovery"
+	"github.com/andrskom/gowfnet/eventlog"
+	"github.com/andrskom/gowfnet/state"
+)
+
+// History of states is exported to XES, imported back, replayed on the net and used for discovery.
+func TestProcessMining_HistoryToXESToConformanceAndDiscovery(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	net := gowfnet.NewNet(orderCfg)
+	clk := clock.NewFake(time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC))
+	log := eventlog.NewLog()
+
+	for i, transitions := range [][]string{{"order", "pay", "pack", "ship"}, {"order", "pack", "pay", "ship"}} {
+		st := state.NewState()
+		st.WithClock(clk)
+
+		r.NoError(net.Start(ctx, st))
+
+		for _, transition := range transitions {
+			clk.Add(time.Minute)
+			r.NoError(net.Transit(ctx, st, transition))
+		}
+
+		r.True(st.IsFinished())
+		log.Add(eventlog.NewTraceFromHistory(strconv.Itoa(i), st.GetHistory(), map[string]string{"customer": "bob"}))
+	}
+
+	var buf bytes.Buffer
+
+	r.NoError(eventlog.WriteXES(&buf, log))
+
+	imported, err := eventlog.ReadXES(&buf)
+	r.NoError(err)
+	r.Equal([]string{"order", "pay", "pack", "ship"}, imported.Traces[0].GetActivities())
+	r.Equal("bob", imported.Traces[1].Attributes["customer"])
+	r.Equal(time.Date(2020, 1, 1, 10, 1, 0, 0, time.UTC), imported.Traces[0].Events[0].Timestamp)
+
+	res := conformance.New(net.GetCfg()).Check(imported)
+	r.Equal(1.0, res.Fitness)
+
+	discovered, err := discovery.Alpha(imported)
+	r.NoError(err)
+	r.Equal(1.0, conformance.New(discovered).Check(imported).Fitness)
+}
@@ -0,0 +1,119 @@
+// nolint:gochecknoglobals
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet"
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/clock"
+	"github.com/andrskom/gowfnet/listener/channel"
+	"github.com/andrskom/gowfnet/state"
+)
+
+var reviewCycleCfg = cfg.Minimal{
+	Start:  "start",
+	Finish: "finish",
+	Places: []cfg.StringID{"start", "inReview", "finish"},
+	Transitions: cfg.MinimalTransitionRegistry{
+		"toReview": {
+			From: []cfg.StringID{"start"},
+			To:   []cfg.StringID{"inReview"},
+		},
+		"requestChanges": {
+			From: []cfg.StringID{"inReview"},
+			To:   []cfg.StringID{"start"},
+		},
+		"approve": {
+			From: []cfg.StringID{"inReview"},
+			To:   []cfg.StringID{"finish"},
+		},
+	},
+}
+
+var documentCfg = cfg.Minimal{
+	Start:  "start",
+	Finish: "finish",
+	Places: []cfg.StringID{"start", "drafted", "finish"},
+	Transitions: cfg.MinimalTransitionRegistry{
+		"draftReview": {
+			From:       []cfg.StringID{"start"},
+			To:         []cfg.StringID{"drafted"},
+			Subprocess: "reviewCycle",
+		},
+		"legalReview": {
+			From:       []cfg.StringID{"drafted"},
+			To:         []cfg.StringID{"finish"},
+			Subprocess: "reviewCycle",
+		},
+	},
+}
+
+func TestSubprocess_ReviewCycleIsReused(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	registry := cfg.NewRegistry()
+	r.NoError(registry.AddWithName("reviewCycle", reviewCycleCfg))
+
+	listener := channel.New(100)
+	net := gowfnet.NewNet(documentCfg)
+	net.WithRegistry(registry)
+	net.WithListener(listener)
+
+	st := state.NewState()
+	st.WithClock(clock.NewFake(time.Date(2020, 10, 4, 0, 0, 0, 0, time.UTC)))
+
+	r.NoError(net.Start(ctx, st))
+	r.NoError(net.Transit(ctx, st, "draftReview"))
+	r.NoError(net.TransitSubprocess(ctx, st, []string{"draftReview"}, "toReview"))
+
+	data, err := json.Marshal(st)
+	r.NoError(err)
+	r.JSONEq(
+		`{"places":["start"],"errStack":{"stack":[]},"isFinished":false,"children":{`+
+			`"draftReview":{"places":["inReview"],"errStack":{"stack":[]},"isFinished":false,"history":[`+
+			`{"transitionId":"toReview","from":["start"],"to":["inReview"],"at":"2020-10-04T00:00:00Z"}]}}}`,
+		string(data),
+	)
+
+	var restored state.State
+
+	r.NoError(json.Unmarshal(data, &restored))
+	r.NoError(net.TransitSubprocess(ctx, &restored, []string{"draftReview"}, "approve"))
+	r.Equal([]string{"drafted"}, restored.GetPlaces())
+
+	r.NoError(net.Transit(ctx, &restored, "legalReview"))
+	r.NoError(net.TransitSubprocess(ctx, &restored, []string{"legalReview"}, "toReview"))
+	r.NoError(net.TransitSubprocess(ctx, &restored, []string{"legalReview"}, "requestChanges"))
+	r.NoError(net.TransitSubprocess(ctx, &restored, []string{"legalReview"}, "toReview"))
+	r.NoError(net.TransitSubprocess(ctx, &restored, []string{"legalReview"}, "approve"))
+	r.True(restored.IsFinished())
+
+	r.Equal("start", listener.ReadEvt())
+	r.Equal("move_FROM:[]_TO:[start]", listener.ReadEvt())
+	r.Equal("moved_FROM:[]_TO:[start]", listener.ReadEvt())
+	r.Equal("started", listener.ReadEvt())
+	r.Equal("transit_draftReview", listener.ReadEvt())
+	r.Equal("start", listener.ReadEvt())
+	r.Equal("move_FROM:[]_TO:[start]", listener.ReadEvt())
+	r.Equal("moved_FROM:[]_TO:[start]", listener.ReadEvt())
+	r.Equal("started", listener.ReadEvt())
+	r.Equal("transit_toReview", listener.ReadEvt())
+	r.Equal("move_FROM:[start]_TO:[inReview]", listener.ReadEvt())
+	r.Equal("moved_FROM:[start]_TO:[inReview]", listener.ReadEvt())
+	r.Equal("toReview_transited", listener.ReadEvt())
+	r.Equal("transit_approve", listener.ReadEvt())
+	r.Equal("move_FROM:[inReview]_TO:[finish]", listener.ReadEvt())
+	r.Equal("moved_FROM:[inReview]_TO:[finish]", listener.ReadEvt())
+	r.Equal("finished", listener.ReadEvt())
+	r.Equal("approve_transited", listener.ReadEvt())
+	r.Equal("move_FROM:[start]_TO:[drafted]", listener.ReadEvt())
+	r.Equal("moved_FROM:[start]_TO:[drafted]", listener.ReadEvt())
+	r.Equal("draftReview_transited", listener.ReadEvt())
+}
@@ -0,0 +1,75 @@
+package gowfnet
+
+import (
+	"context"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/state"
+)
+
+// UndoListenerInterface is an optional extension of ListenerInterface.
+// BeforeUndo can forbid undo by returning err.
+type UndoListenerInterface interface {
+	BeforeUndo(ctx context.Context, transitionID string, state StateOpInterface) error
+	AfterUndo(ctx context.Context, transitionID string, state StateOpInterface)
+}
+
+// Undo reverses the last transition of the state.
+//
+// Tokens are moved back from output places of the transition to its input places, cancelled tokens are restored.
+// The transition must be reversible in the config and the state must implement HistoryStateInterface.
+// Ctx of listeners contains the id of the undone transition, see state.GetTransitionID.
+func (n *Net) Undo(ctx context.Context, s StateInterface) error {
+	historyState, ok := s.(HistoryStateInterface)
+	if !ok {
+		return state.NewError(state.ErrCodeNetStateDoesntSupportHistory, "State doesn't support history")
+	}
+
+	if s.IsError() {
+		return state.NewError(state.ErrCodeStateIsErrorState, "Can't undo, state is errStack")
+	}
+
+	history := historyState.GetHistory()
+	if len(history) == 0 {
+		return state.NewError(state.ErrCodeStateHasNotHistory, "Can't undo, state has not history")
+	}
+
+	transitionID := history[len(history)-1].TransitionID
+
+	if !n.isReversible(transitionID) {
+		return state.NewErrorf(
+			state.ErrCodeNetTransitionIsNotReversible,
+			"Can't undo, transition '%s' is not reversible",
+			transitionID,
+		)
+	}
+
+	trCtx := state.WithTransitionID(ctx, transitionID)
+	listener, hasUndoListener := n.listener.(UndoListenerInterface)
+
+	if hasUndoListener {
+		if err := listener.BeforeUndo(trCtx, transitionID, s); err != nil {
+			return err
+		}
+	}
+
+	if n.listener.HasStateListener() {
+		s.WithListener(n.listener.GetStateListener())
+	}
+
+	if _, err := historyState.RevertLast(trCtx); err != nil {
+		return err
+	}
+
+	if hasUndoListener {
+		listener.AfterUndo(trCtx, transitionID, s)
+	}
+
+	return nil
+}
+
+func (n *Net) isReversible(transitionID string) bool {
+	reversible, ok := n.transitionMap[transitionID].(cfg.ReversibleTransitionInterface)
+
+	return ok && reversible.IsReversible()
+}
@@ -0,0 +1,110 @@
+package gowfnet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrskom/gowfnet/cfg"
+	"github.com/andrskom/gowfnet/state"
+)
+
+type testingUndoListener struct {
+	*StubListener
+	events    []string
+	beforeErr error
+}
+
+func (l *testingUndoListener) BeforeUndo(ctx context.Context, transitionID string, s StateOpInterface) error {
+	ctxTransitionID, _ := state.GetTransitionID(ctx)
+	l.events = append(l.events, "before:"+transitionID+":"+ctxTransitionID)
+
+	return l.beforeErr
+}
+
+func (l *testingUndoListener) AfterUndo(ctx context.Context, transitionID string, s StateOpInterface) {
+	l.events = append(l.events, "after:"+transitionID)
+}
+
+func newUndoNet(t *testing.T) (*Net, *state.State) {
+	net := NewNet(cfg.Minimal{
+		Start:  "start",
+		Finish: "finish",
+		Places: []cfg.StringID{"start", "draft", "finish"},
+		Transitions: cfg.MinimalTransitionRegistry{
+			"write":   {From: []cfg.StringID{"start"}, To: []cfg.StringID{"draft"}},
+			"publish": {From: []cfg.StringID{"draft"}, To: []cfg.StringID{"finish"}, Reversible: true},
+		},
+	})
+
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+	require.NoError(t, net.Transit(context.Background(), st, "write"))
+
+	return net, st
+}
+
+func TestNet_Undo_ReversibleTransition_Undone(t *testing.T) {
+	net, st := newUndoNet(t)
+	require.NoError(t, net.Transit(context.Background(), st, "publish"))
+
+	listener := &testingUndoListener{StubListener: NewStubListener()}
+	net.WithListener(listener)
+
+	require.NoError(t, net.Undo(context.Background(), st))
+	assert.Equal(t, []string{"draft"}, st.GetPlaces())
+	assert.False(t, st.IsFinished())
+	assert.Equal(t, []string{"before:publish:publish", "after:publish"}, listener.events)
+}
+
+func TestNet_Undo_NotReversibleTransition_ExpectedErr(t *testing.T) {
+	net, st := newUndoNet(t)
+
+	err := net.Undo(context.Background(), st)
+	assert.Equal(
+		t,
+		state.NewError(state.ErrCodeNetTransitionIsNotReversible, "Can't undo, transition 'write' is not reversible"),
+		err,
+	)
+	assert.Equal(t, []string{"draft"}, st.GetPlaces())
+}
+
+func TestNet_Undo_BeforeUndoErr_TheSameErr(t *testing.T) {
+	net, st := newUndoNet(t)
+	require.NoError(t, net.Transit(context.Background(), st, "publish"))
+
+	eErr := errors.New("a")
+	net.WithListener(&testingUndoListener{StubListener: NewStubListener(), beforeErr: eErr})
+
+	assert.Same(t, eErr, net.Undo(context.Background(), st))
+	assert.Equal(t, []string{"finish"}, st.GetPlaces())
+}
+
+func TestNet_Undo_WithoutHistory_ExpectedErr(t *testing.T) {
+	net, _ := newUndoNet(t)
+	st := state.NewState()
+	require.NoError(t, net.Start(context.Background(), st))
+
+	err := net.Undo(context.Background(), st)
+	assert.Equal(t, state.NewError(state.ErrCodeStateHasNotHistory, "Can't undo, state has not history"), err)
+}
+
+func TestNet_Undo_ErrState_ExpectedErr(t *testing.T) {
+	net, st := newUndoNet(t)
+	st.AddError(errors.New("a"))
+
+	err := net.Undo(context.Background(), st)
+	assert.Equal(t, state.NewError(state.ErrCodeStateIsErrorState, "Can't undo, state is errStack"), err)
+}
+
+func TestNet_Undo_StateWithoutHistory_ExpectedErr(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net, _ := newUndoNet(t)
+
+	err := net.Undo(context.Background(), NewMockStateInterface(ctrl))
+	assert.Equal(t, state.NewError(state.ErrCodeNetStateDoesntSupportHistory, "State doesn't support history"), err)
+}